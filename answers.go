@@ -0,0 +1,150 @@
+package disgo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// ErrMissingAnswer is returned by prompts when the terminal has scripted
+// answers and is not interactive, but no answer was scripted for a prompt
+// that has no default value.
+var ErrMissingAnswer = errors.New("no scripted answer for prompt")
+
+// WithAnswers scripts answers to prompts, so that they return without
+// waiting for user input. Answers are matched with prompts by their key
+// first, and then by their label. They are parsed like user input would be.
+//
+// When a terminal has scripted answers and is not interactive, prompts
+// without a default value for which no answer was scripted return
+// ErrMissingAnswer, instead of silently returning their default value.
+func WithAnswers(answers map[string]string) func(*Terminal) {
+	return func(term *Terminal) {
+		// Copy the answers, so that child terminals never
+		// modify their parent's answers.
+		merged := make(map[string]string, len(term.answers)+len(answers))
+		for key, answer := range term.answers {
+			merged[key] = answer
+		}
+		for key, answer := range answers {
+			merged[key] = answer
+		}
+		term.answers = merged
+	}
+}
+
+// WithAnswersFile scripts answers to prompts from a JSON or YAML file,
+// depending on its extension, which contains an object that maps prompt
+// keys or labels to answers. See WithAnswers for details.
+//
+// If the file can't be loaded, all prompts return an error.
+func WithAnswersFile(path string) func(*Terminal) {
+	answers, err := LoadAnswers(path)
+	if err != nil {
+		return func(term *Terminal) {
+			term.answersErr = err
+		}
+	}
+
+	return WithAnswers(answers)
+}
+
+// WithAnswersFromEnv scripts answers to prompts from environment variables
+// whose names start with the given prefix, followed by the key or label of
+// a prompt in upper case, with all characters that are not letters or digits
+// replaced with underscores. For example, with the `MYTOOL_` prefix, the
+// answer to a prompt with the `install` key is read from `MYTOOL_INSTALL`.
+// Answers given with WithAnswers take precedence over environment variables.
+func WithAnswersFromEnv(prefix string) func(*Terminal) {
+	return func(term *Terminal) {
+		term.answersEnvPrefix = prefix
+	}
+}
+
+// LoadAnswers loads scripted answers from a JSON or YAML file,
+// depending on its extension.
+func LoadAnswers(path string) (map[string]string, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read answers file: %v", err)
+	}
+
+	// Answers are decoded as any value, since YAML parses
+	// values such as `yes` or `1` as booleans and numbers.
+	var values map[string]interface{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(content, &values)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &values)
+	default:
+		return nil, fmt.Errorf("unsupported answers file extension %q", filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse answers file: %v", err)
+	}
+
+	answers := make(map[string]string, len(values))
+	for key, value := range values {
+		answers[key] = fmt.Sprint(value)
+	}
+
+	return answers, nil
+}
+
+// scripted returns whether or not the terminal has scripted
+// or replayed answers.
+func (t Terminal) scripted() bool {
+	return t.answers != nil || t.answersEnvPrefix != "" || t.answersErr != nil || t.replay != nil
+}
+
+// scriptedAnswer returns the answer that was scripted for the prompt
+// with the given key and label, if there is one.
+func (t Terminal) scriptedAnswer(key, label string) (string, bool, error) {
+	if t.answersErr != nil {
+		return "", false, t.answersErr
+	}
+
+	var candidates []string
+	if key != "" {
+		candidates = append(candidates, key)
+	}
+	candidates = append(candidates, label)
+
+	for _, candidate := range candidates {
+		if answer, ok := t.answers[candidate]; ok {
+			return answer, true, nil
+		}
+	}
+
+	if t.answersEnvPrefix == "" {
+		return "", false, nil
+	}
+
+	for _, candidate := range candidates {
+		if answer, ok := os.LookupEnv(t.answersEnvPrefix + envName(candidate)); ok {
+			return answer, true, nil
+		}
+	}
+
+	return "", false, nil
+}
+
+// envName converts a prompt key or label to the name
+// of an environment variable.
+func envName(name string) string {
+	name = strings.TrimSpace(name)
+	return strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, name)
+}
@@ -0,0 +1,198 @@
+package disgo
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithAnswers(t *testing.T) {
+	testCases := []struct {
+		desc           string
+		answers        map[string]string
+		interactive    bool
+		config         Confirmation
+		expectedResult bool
+		expectedError  error
+		expectsError   bool
+	}{
+		{
+			desc:           "matches by key",
+			answers:        map[string]string{"install": "y"},
+			config:         Confirmation{Key: "install", Label: "Install?"},
+			expectedResult: true,
+		},
+		{
+			desc:           "key takes precedence over label",
+			answers:        map[string]string{"install": "y", "Install?": "n"},
+			config:         Confirmation{Key: "install", Label: "Install?"},
+			expectedResult: true,
+		},
+		{
+			desc:           "matches by label",
+			answers:        map[string]string{"Install?": "yes"},
+			config:         Confirmation{Key: "install", Label: "Install?"},
+			expectedResult: true,
+		},
+		{
+			desc:           "empty answer uses default value",
+			answers:        map[string]string{"install": ""},
+			config:         Confirmation{Key: "install", EnableDefaultValue: true, DefaultValue: true},
+			expectedResult: true,
+		},
+		{
+			desc:         "invalid answer",
+			answers:      map[string]string{"install": "maybe"},
+			config:       Confirmation{Key: "install"},
+			expectsError: true,
+		},
+		{
+			desc:          "missing answer without default value",
+			answers:       map[string]string{"other": "y"},
+			config:        Confirmation{Key: "install"},
+			expectedError: ErrMissingAnswer,
+			expectsError:  true,
+		},
+		{
+			desc:           "missing answer with default value",
+			answers:        map[string]string{"other": "y"},
+			config:         Confirmation{Key: "install", EnableDefaultValue: true, DefaultValue: true},
+			expectedResult: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defaultOut := &bytes.Buffer{}
+
+			term := NewTerminal(WithDefaultOutput(defaultOut), WithInteractive(false), WithAnswers(test.answers))
+
+			result, err := term.Confirm(test.config)
+			if test.expectsError {
+				require.Error(t, err)
+				if test.expectedError != nil {
+					assert.True(t, errors.Is(err, test.expectedError))
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResult, result)
+		})
+	}
+}
+
+func TestScriptedAnswerOutput(t *testing.T) {
+	defaultOut := &bytes.Buffer{}
+	teeOut := &bytes.Buffer{}
+
+	term := NewTerminal(
+		WithDefaultOutput(defaultOut),
+		WithTee(teeOut),
+		WithDebug(true),
+		WithAnswers(map[string]string{"install": "y"}),
+	)
+
+	result, err := term.Confirm(Confirmation{Key: "install", Label: "Install?"})
+	require.NoError(t, err)
+	assert.True(t, result)
+
+	assert.Equal(t, "Install? [y/n] y\nAnswered \"Install?\" automatically with \"y\"\n", defaultOut.String())
+	assert.Contains(t, teeOut.String(), "PROMPT Install? [y/n] y (scripted)\n")
+}
+
+func TestWithAnswersDoesNotAffectParent(t *testing.T) {
+	parent := NewTerminal(WithAnswers(map[string]string{"first": "y"}))
+	child := parent.With(WithAnswers(map[string]string{"second": "y"}))
+
+	assert.Len(t, parent.answers, 1)
+	assert.Len(t, child.answers, 2)
+}
+
+func TestWithAnswersFromEnv(t *testing.T) {
+	require.NoError(t, os.Setenv("DISGO_TEST_INSTALL", "n"))
+	require.NoError(t, os.Setenv("DISGO_TEST_DELETE_EVERYTHING_", "y"))
+	defer os.Unsetenv("DISGO_TEST_INSTALL")
+	defer os.Unsetenv("DISGO_TEST_DELETE_EVERYTHING_")
+
+	term := NewTerminal(WithDefaultOutput(&bytes.Buffer{}), WithInteractive(false), WithAnswersFromEnv("DISGO_TEST_"))
+
+	result, err := term.Confirm(Confirmation{Key: "install", DefaultValue: true})
+	require.NoError(t, err)
+	assert.False(t, result)
+
+	result, err = term.Confirm(Confirmation{Label: "Delete everything?"})
+	require.NoError(t, err)
+	assert.True(t, result)
+
+	_, err = term.Confirm(Confirmation{Key: "missing"})
+	assert.True(t, errors.Is(err, ErrMissingAnswer))
+}
+
+func TestLoadAnswers(t *testing.T) {
+	dir, err := ioutil.TempDir("", "disgo")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	testCases := []struct {
+		desc         string
+		file         string
+		content      string
+		expected     map[string]string
+		expectsError bool
+	}{
+		{
+			desc:     "json",
+			file:     "answers.json",
+			content:  `{"install": "y", "Delete everything?": false}`,
+			expected: map[string]string{"install": "y", "Delete everything?": "false"},
+		},
+		{
+			desc:     "yaml",
+			file:     "answers.yml",
+			content:  "install: yes\nretries: 3\n",
+			expected: map[string]string{"install": "true", "retries": "3"},
+		},
+		{
+			desc:         "invalid json",
+			file:         "invalid.json",
+			content:      `{"install"`,
+			expectsError: true,
+		},
+		{
+			desc:         "unsupported extension",
+			file:         "answers.txt",
+			content:      "install=y",
+			expectsError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			path := filepath.Join(dir, test.file)
+			require.NoError(t, ioutil.WriteFile(path, []byte(test.content), 0644))
+
+			answers, err := LoadAnswers(path)
+			if test.expectsError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, answers)
+		})
+	}
+}
+
+func TestWithAnswersFileError(t *testing.T) {
+	term := NewTerminal(WithAnswersFile("does/not/exist.yaml"))
+
+	_, err := term.Confirm(Confirmation{Label: "Install?"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,127 @@
+package disgo
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Default dimensions of cast recordings, used when they can't
+// be read from the environment or detected from the terminal.
+const (
+	defaultCastWidth  = 80
+	defaultCastHeight = 24
+)
+
+// WithCastRecorder records everything that the terminal prints on the
+// given writer, in the asciicast v2 format that asciinema can play.
+// Outputs are recorded as they are shown to the user, including escape
+// sequences, with the time at which they were written. Answers to
+// prompts are recorded as if they were echoed by a terminal.
+//
+// The dimensions of the recording are read when the terminal first
+// prints something, from the COLUMNS and LINES environment variables,
+// or from the terminal's default writer if it is a TTY. They default to
+// 80 columns and 24 rows. Child terminals record on the same writer as
+// their parent.
+func WithCastRecorder(w io.Writer) func(*Terminal) {
+	return func(term *Terminal) {
+		term.cast = &castRecorder{
+			w: w,
+		}
+	}
+}
+
+// castSize returns the dimensions of cast recordings
+// made from what the terminal prints.
+func (t Terminal) castSize() (int, int) {
+	width, height := defaultCastWidth, defaultCastHeight
+	if columns, rows, ok := t.ttySize(); ok {
+		width, height = columns, rows
+	}
+
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		width = columns
+	}
+	if lines, err := strconv.Atoi(os.Getenv("LINES")); err == nil && lines > 0 {
+		height = lines
+	}
+	return width, height
+}
+
+// castRecorder writes what a terminal prints as an asciicast v2
+// recording, which is a header line followed by one line per write.
+type castRecorder struct {
+	mu    sync.Mutex
+	w     io.Writer
+	start time.Time
+}
+
+// castHeader is the first line of an asciicast v2 recording.
+type castHeader struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// record writes content that was printed at the given time. The header
+// is written along with the first content, whose time is the start of
+// the recording, with the dimensions that size returns. Write errors
+// are ignored, like on the terminal's own writers.
+func (c *castRecorder) record(now time.Time, content string, size func() (int, int)) {
+	if content == "" {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Build the whole record before writing it, and don't escape
+	// HTML characters, which players would show as is.
+	var record bytes.Buffer
+	encoder := json.NewEncoder(&record)
+	encoder.SetEscapeHTML(false)
+
+	if c.start.IsZero() {
+		c.start = now
+
+		width, height := size()
+		header := castHeader{
+			Version:   2,
+			Width:     width,
+			Height:    height,
+			Timestamp: now.Unix(),
+		}
+		if term := os.Getenv("TERM"); term != "" {
+			header.Env = map[string]string{"TERM": term}
+		}
+		encoder.Encode(header)
+	}
+
+	// Terminals translate line feeds into carriage returns followed by
+	// line feeds, which players expect to find in recordings.
+	content = strings.Replace(content, "\r\n", "\n", -1)
+	content = strings.Replace(content, "\n", "\r\n", -1)
+
+	elapsed := float64(now.Sub(c.start).Microseconds()) / 1e6
+	encoder.Encode([]interface{}{elapsed, "o", content})
+
+	c.w.Write(record.Bytes())
+}
+
+// echo records text that the user typed, as a terminal
+// would have echoed it, with its secrets masked.
+func (t Terminal) echo(text string) {
+	if t.cast == nil {
+		return
+	}
+
+	t.cast.record(t.now(), t.redactor.Redact(text), t.castSize)
+}
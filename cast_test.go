@@ -0,0 +1,85 @@
+package disgo
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithCastRecorder(t *testing.T) {
+	require.NoError(t, os.Setenv("COLUMNS", "120"))
+	require.NoError(t, os.Setenv("LINES", "not a number"))
+	defer os.Unsetenv("COLUMNS")
+	defer os.Unsetenv("LINES")
+
+	cast := &bytes.Buffer{}
+	term := NewTerminal(
+		WithDefaultOutput(&bytes.Buffer{}),
+		WithErrorOutput(&bytes.Buffer{}),
+		WithReader(strings.NewReader("hunter2\n")),
+		WithCastRecorder(cast),
+	)
+	term.clock = fakeClock(time.Date(2019, 1, 1, 12, 0, 0, 0, time.UTC))
+	term.RegisterSecret("hunter2")
+
+	term.StartStep("Installing")
+	term.Errorln("first\nsecond")
+	term.EndStep()
+	term.Info("\rLoading\x1b[K")
+	_, _ = term.Confirm(Confirmation{Label: "Password?"})
+
+	lines := strings.Split(strings.TrimSuffix(cast.String(), "\n"), "\n")
+	require.Len(t, lines, 7)
+
+	var header map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &header))
+	assert.Equal(t, float64(2), header["version"])
+	assert.Equal(t, float64(120), header["width"])
+	assert.Equal(t, float64(24), header["height"])
+	assert.Equal(t, float64(1546344000), header["timestamp"])
+
+	assert.Equal(t, `[0,"o","Installing..."]`, lines[1])
+	assert.Equal(t, `[1,"o","ok\r\n"]`, lines[2])
+	assert.Equal(t, `[2,"o","  > first\r\n    second\r\n"]`, lines[3])
+	assert.Equal(t, `[3,"o","\rLoading\u001b[K"]`, lines[4])
+	assert.Equal(t, `[4,"o","Password? [y/n] "]`, lines[5])
+	assert.Equal(t, `[5,"o","****\r\n"]`, lines[6])
+}
+
+func TestWithCastRecorderChildTerminal(t *testing.T) {
+	cast := &bytes.Buffer{}
+	term := NewTerminal(WithDefaultOutput(&bytes.Buffer{}), WithCastRecorder(cast))
+
+	term.Scope("plugin").Infoln("scoped")
+	term.Infoln("parent")
+
+	assert.Contains(t, cast.String(), `"o","[plugin] scoped\r\n"]`)
+	assert.Contains(t, cast.String(), `"o","parent\r\n"]`)
+}
+
+func TestWithCastRecorderSize(t *testing.T) {
+	defer os.Unsetenv("COLUMNS")
+	defer os.Unsetenv("LINES")
+
+	cast := &bytes.Buffer{}
+	term := NewTerminal(WithCastRecorder(cast), WithDefaultOutput(&bytes.Buffer{}))
+
+	// The size should be read when the header is written,
+	// rather than when the terminal is created.
+	require.NoError(t, os.Setenv("COLUMNS", "100"))
+	require.NoError(t, os.Setenv("LINES", "40"))
+	term.Infoln("first")
+	require.NoError(t, os.Setenv("COLUMNS", "60"))
+	term.Infoln("second")
+
+	var header map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(strings.SplitN(cast.String(), "\n", 2)[0]), &header))
+	assert.Equal(t, float64(100), header["width"])
+	assert.Equal(t, float64(40), header["height"])
+}
@@ -0,0 +1,105 @@
+package disgo
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Ullaakut/disgo/style"
+	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
+)
+
+// colorPolicy returns whether or not colors should be written on the
+// given writer, and whether that could be decided at all. Unless they
+// were explicitly enabled or disabled using WithColors, the policy
+// follows common conventions, in this order of precedence:
+//
+//  1. NO_COLOR set to any non-empty value disables colors.
+//  2. CLICOLOR_FORCE or FORCE_COLOR set to any non-empty value
+//     other than `0` or `false` enables colors.
+//  3. CLICOLOR set to `0` disables colors.
+//  4. TERM set to `dumb` disables colors.
+//  5. Writers that are files, such as os.Stdout and os.Stderr, get
+//     colors only if they are terminals, so that redirecting one of
+//     them does not affect the other.
+//
+// For other writers, such as buffers, it can't be decided, and the
+// outputs are written as they are.
+func (t Terminal) colorPolicy(w io.Writer) (enabled bool, decided bool) {
+	switch t.colors {
+	case colorsEnabled:
+		return true, true
+	case colorsDisabled:
+		return false, true
+	}
+
+	if enabled, decided := environmentColors(); decided {
+		return enabled, true
+	}
+
+	if file, ok := w.(interface{ Fd() uintptr }); ok {
+		return isatty.IsTerminal(file.Fd()) || isatty.IsCygwinTerminal(file.Fd()), true
+	}
+
+	return false, false
+}
+
+// environmentColors returns whether or not colors are enabled
+// by the environment variables of the color policy, and whether
+// any of them is set at all.
+func environmentColors() (enabled bool, decided bool) {
+	if os.Getenv("NO_COLOR") != "" {
+		return false, true
+	}
+	if forced("CLICOLOR_FORCE") || forced("FORCE_COLOR") {
+		return true, true
+	}
+	if os.Getenv("CLICOLOR") == "0" {
+		return false, true
+	}
+	if os.Getenv("TERM") == "dumb" {
+		return false, true
+	}
+	return false, false
+}
+
+// forced returns whether or not the given environment
+// variable is set to force colors.
+func forced(name string) bool {
+	value := strings.ToLower(os.Getenv(name))
+	return value != "" && value != "0" && value != "false"
+}
+
+// stripColors returns whether or not ANSI escape sequences
+// should be stripped from the outputs written on w.
+func (t Terminal) stripColors(w io.Writer) bool {
+	enabled, decided := t.colorPolicy(w)
+	return decided && !enabled
+}
+
+// sprint applies a style to its arguments if colors are enabled on w.
+// If the policy can't be decided for w, it follows the package-wide
+// setting, like the outputs written on w do.
+func (t Terminal) sprint(w io.Writer, s style.Style, a ...interface{}) string {
+	enabled, decided := t.colorPolicy(w)
+	switch {
+	case !decided:
+		return s.Sprint(a...)
+	case enabled:
+		return s.Render(a...)
+	}
+	return fmt.Sprint(a...)
+}
+
+// applyColorEnvironment makes the style package follow the environment
+// variables of the color policy, which apply to the whole process, so
+// that content styled before being written on terminals gets colors when
+// they are forced, for example. Writers that don't get colors are
+// decided for each terminal, and their outputs are stripped in write.
+func applyColorEnvironment() {
+	if enabled, decided := environmentColors(); decided {
+		color.NoColor = !enabled
+	}
+}
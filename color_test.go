@@ -0,0 +1,212 @@
+package disgo
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/Ullaakut/disgo/style"
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// colorVariables are the environment variables that
+// the color policy depends on.
+var colorVariables = []string{"NO_COLOR", "CLICOLOR", "CLICOLOR_FORCE", "FORCE_COLOR", "TERM"}
+
+// setColorEnv unsets all of the color variables except for the
+// given ones, and returns a function that restores them.
+func setColorEnv(t *testing.T, env map[string]string) func() {
+	saved := make(map[string]string)
+	for _, name := range colorVariables {
+		if value, ok := os.LookupEnv(name); ok {
+			saved[name] = value
+		}
+		require.NoError(t, os.Unsetenv(name))
+	}
+	for name, value := range env {
+		require.NoError(t, os.Setenv(name, value))
+	}
+
+	return func() {
+		for _, name := range colorVariables {
+			os.Unsetenv(name)
+			if value, ok := saved[name]; ok {
+				os.Setenv(name, value)
+			}
+		}
+	}
+}
+
+func TestColorPolicy(t *testing.T) {
+	_, pipe, err := os.Pipe()
+	require.NoError(t, err)
+	defer pipe.Close()
+
+	testCases := []struct {
+		desc            string
+		env             map[string]string
+		colors          colorMode
+		buffer          bool
+		expectedEnabled bool
+		expectedDecided bool
+	}{
+		{
+			desc:            "redirected file",
+			expectedDecided: true,
+		},
+		{
+			desc:            "buffer",
+			buffer:          true,
+			expectedDecided: false,
+		},
+		{
+			desc:            "FORCE_COLOR",
+			env:             map[string]string{"FORCE_COLOR": "1"},
+			expectedEnabled: true,
+			expectedDecided: true,
+		},
+		{
+			desc:            "FORCE_COLOR set to false",
+			env:             map[string]string{"FORCE_COLOR": "false"},
+			expectedDecided: true,
+		},
+		{
+			desc:            "NO_COLOR takes precedence over FORCE_COLOR",
+			env:             map[string]string{"NO_COLOR": "1", "FORCE_COLOR": "1"},
+			expectedDecided: true,
+		},
+		{
+			desc:            "CLICOLOR_FORCE takes precedence over TERM=dumb",
+			env:             map[string]string{"CLICOLOR_FORCE": "1", "TERM": "dumb"},
+			expectedEnabled: true,
+			expectedDecided: true,
+		},
+		{
+			desc:            "CLICOLOR=0",
+			env:             map[string]string{"CLICOLOR": "0"},
+			buffer:          true,
+			expectedDecided: true,
+		},
+		{
+			desc:            "TERM=dumb",
+			env:             map[string]string{"TERM": "dumb"},
+			buffer:          true,
+			expectedDecided: true,
+		},
+		{
+			desc:            "WithColors takes precedence over the environment",
+			env:             map[string]string{"NO_COLOR": "1"},
+			colors:          colorsEnabled,
+			expectedEnabled: true,
+			expectedDecided: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer setColorEnv(t, test.env)()
+
+			term := Terminal{colors: test.colors}
+
+			var enabled, decided bool
+			if test.buffer {
+				enabled, decided = term.colorPolicy(&bytes.Buffer{})
+			} else {
+				enabled, decided = term.colorPolicy(pipe)
+			}
+
+			assert.Equal(t, test.expectedEnabled, enabled)
+			assert.Equal(t, test.expectedDecided, decided)
+		})
+	}
+}
+
+func TestColorPolicyPerWriter(t *testing.T) {
+	defer setColorEnv(t, nil)()
+
+	color.NoColor = false
+	defer func() {
+		color.NoColor = true
+	}()
+
+	reader, pipe, err := os.Pipe()
+	require.NoError(t, err)
+	defer reader.Close()
+
+	defaultOut := &bytes.Buffer{}
+	term := NewTerminal(WithDefaultOutput(defaultOut), WithErrorOutput(pipe))
+
+	green := color.New(color.FgGreen).SprintFunc()
+	term.Infoln(green("kept"))
+	term.Errorln(green("stripped"))
+	require.NoError(t, pipe.Close())
+
+	errorOut, err := ioutil.ReadAll(reader)
+	require.NoError(t, err)
+
+	assert.Equal(t, green("kept")+"\n", defaultOut.String())
+	assert.Equal(t, "stripped\n", string(errorOut))
+}
+
+func TestColorPolicyLeavesPackageSetting(t *testing.T) {
+	defer setColorEnv(t, nil)()
+
+	_, pipe, err := os.Pipe()
+	require.NoError(t, err)
+	defer pipe.Close()
+
+	color.NoColor = false
+	defer func() {
+		color.NoColor = true
+	}()
+
+	// Writers that don't get colors should not disable
+	// them for the other terminals of the process.
+	NewTerminal(WithDefaultOutput(pipe), WithErrorOutput(pipe))
+	assert.False(t, color.NoColor)
+}
+
+func TestApplyColorEnvironment(t *testing.T) {
+	defer func() {
+		color.NoColor = true
+	}()
+
+	restore := setColorEnv(t, map[string]string{"FORCE_COLOR": "1"})
+	applyColorEnvironment()
+	assert.False(t, color.NoColor)
+	restore()
+
+	restore = setColorEnv(t, map[string]string{"NO_COLOR": "1"})
+	applyColorEnvironment()
+	assert.True(t, color.NoColor)
+	restore()
+
+	// Without any variable, the package-wide setting is left as is.
+	color.NoColor = false
+	restore = setColorEnv(t, nil)
+	applyColorEnvironment()
+	assert.False(t, color.NoColor)
+	restore()
+}
+
+func TestSprintPerWriter(t *testing.T) {
+	_, pipe, err := os.Pipe()
+	require.NoError(t, err)
+	defer pipe.Close()
+
+	green := style.Style{"green"}
+
+	// Colors that are forced should be rendered even though
+	// they are disabled package-wide.
+	restore := setColorEnv(t, map[string]string{"FORCE_COLOR": "1"})
+	term := NewTerminal(WithDefaultOutput(pipe))
+	assert.Equal(t, "\x1b[32mok\x1b[0m", term.sprint(pipe, green, "ok"))
+	restore()
+
+	defer setColorEnv(t, nil)()
+	assert.Equal(t, "ok", term.sprint(pipe, green, "ok"))
+	assert.Equal(t, "ok", term.sprint(&bytes.Buffer{}, green, "ok"))
+}
@@ -35,6 +35,12 @@ func DefaultConfirmation(input string) (bool, error) {
 
 // Confirmation represents a confirmation prompt's configuration.
 type Confirmation struct {
+	// Key identifies the prompt when scripting answers.
+	// If it is empty, the prompt can only be identified
+	// by its label.
+	// Example: `install`
+	Key string
+
 	// The label that will be prompted to the user.
 	// Example: `Are you sure?`
 	Label string
@@ -74,30 +80,83 @@ func (c Confirmation) choices() string {
 	return strings.Join(DefaultConfirmationChoices, "/")
 }
 
+// answer converts an answer to the prompt into a confirmation value.
+func (c Confirmation) answer(text string) (bool, error) {
+	// If user just pressed enter directly, return default value.
+	if c.EnableDefaultValue && text == "" {
+		return c.DefaultValue, nil
+	}
+
+	// Parse user input.
+	return c.parser()(strings.TrimSpace(text))
+}
+
 // Confirm prompts the user to confirm something.
 func (t Terminal) Confirm(config Confirmation) (bool, error) {
+	// Replayed and scripted answers take precedence over user input.
+	answer, replayed, err := t.replay.answer(config.Label)
+	if err != nil {
+		return false, err
+	}
+	scripted := false
+	if !replayed {
+		answer, scripted, err = t.scriptedAnswer(config.Key, config.Label)
+		if err != nil {
+			return false, err
+		}
+	}
+	if replayed || scripted {
+		source := "scripted"
+		if replayed {
+			source = "replayed"
+		}
+
+		t.print(t.defaultOutput, fmt.Sprintf("%s [%s] %s\n", config.Label, config.choices(), answer))
+		t.tee(teeTagPrompt, "", fmt.Sprintf("%s [%s] %s (%s)", config.Label, config.choices(), answer, source))
+		t.emit(Event{
+			Kind:   EventPrompt,
+			Text:   config.Label,
+			Answer: answer,
+		})
+		// Replayed sessions should produce the same outputs
+		// as the recorded ones, so they are not logged.
+		if scripted {
+			t.Debugf("Answered %q automatically with %q\n", config.Label, answer)
+		}
+
+		return config.answer(answer)
+	}
+
 	// If terminal is not set to interactive,
 	// directly return the default value.
 	if !t.interactive {
+		if t.scripted() && !config.EnableDefaultValue {
+			return false, fmt.Errorf("%w: %q", ErrMissingAnswer, config.Label)
+		}
 		return config.DefaultValue, nil
 	}
 
 	// Print the label and choices.
-	fmt.Fprintf(t.defaultOutput, "%s [%s] ", config.Label, config.choices())
+	t.print(t.defaultOutput, fmt.Sprintf("%s [%s] ", config.Label, config.choices()))
 
-	// Wait for user input.
+	// Wait for user input. The newline that the user types
+	// ends the line on which the label was printed.
 	text, err := t.reader.ReadString('\n')
+	t.lines.track(t.defaultOutput, "\n")
+	t.echo(text)
+	t.tee(teeTagPrompt, "", fmt.Sprintf("%s [%s] %s", config.Label, config.choices(), strings.TrimSpace(text)))
 	if err != nil {
 		return false, err
 	}
 
-	// If user just pressed enter directly, return default value.
-	if config.EnableDefaultValue && text == "\n" {
-		return config.DefaultValue, nil
-	}
+	text = strings.TrimSuffix(text, "\n")
+	t.emit(Event{
+		Kind:   EventPrompt,
+		Text:   config.Label,
+		Answer: text,
+	})
 
-	// Parse user input.
-	return config.parser()(strings.TrimSpace(text))
+	return config.answer(text)
 }
 
 // Confirm prompts the user to confirm something
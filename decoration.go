@@ -0,0 +1,123 @@
+package disgo
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Ullaakut/disgo/style"
+)
+
+// lineTracker keeps track of the writers on which the last
+// output did not end with a newline, so that the next output
+// is known to continue an existing line.
+type lineTracker struct {
+	mu      sync.Mutex
+	midLine map[io.Writer]bool
+}
+
+// continues returns whether or not an output written on w
+// would continue an existing line. It is safe to call on
+// a nil tracker, in which case outputs always start new lines.
+func (l *lineTracker) continues(w io.Writer) bool {
+	if l == nil || !trackable(w) {
+		return false
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.midLine[w]
+}
+
+// track records whether or not the given content, once
+// written on w, leaves it in the middle of a line.
+func (l *lineTracker) track(w io.Writer, content string) {
+	if l == nil || content == "" || !trackable(w) {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.midLine == nil {
+		l.midLine = make(map[io.Writer]bool)
+	}
+	l.midLine[w] = !strings.HasSuffix(content, "\n")
+}
+
+// trackable returns whether or not w can be used as a key
+// to keep track of its lines, which requires it to be comparable.
+func trackable(w io.Writer) bool {
+	return w != nil && reflect.TypeOf(w).Comparable()
+}
+
+// now returns the current time according to the terminal's clock.
+func (t Terminal) now() time.Time {
+	if t.clock == nil {
+		return time.Now()
+	}
+	return t.clock()
+}
+
+// decoration returns the timestamp and prefix that should be
+// prepended to lines produced right now, or an empty string
+// if the terminal does not decorate its outputs.
+func (t Terminal) decoration() string {
+	var decoration string
+	if t.timestampLayout != "" {
+		decoration += t.now().Format(t.timestampLayout) + " "
+	}
+	if t.prefix != "" {
+		decoration += t.prefix + " "
+	}
+	return decoration
+}
+
+// decorate prepends the given decoration to each line of
+// content, except for the first one if it continues a line
+// that was already started on w.
+func (t Terminal) decorate(w io.Writer, content, decoration string) string {
+	if decoration == "" {
+		return content
+	}
+
+	lines := strings.SplitAfter(content, "\n")
+	for i, line := range lines {
+		// SplitAfter returns an empty last element when content
+		// ends with a newline, which should not be decorated.
+		if line == "" || (i == 0 && t.lines.continues(w)) {
+			continue
+		}
+		lines[i] = decoration + line
+	}
+
+	return strings.Join(lines, "")
+}
+
+// print decorates content and writes it on w.
+func (t Terminal) print(w io.Writer, content string) {
+	t.write(w, t.decorate(w, content, t.decoration()))
+}
+
+// write writes content on w as is, except for secrets which are
+// masked and colors which are stripped if they are disabled on the
+// terminal, in which case hyperlinks are rewritten as their text
+// followed by their URL. It keeps track of whether or not it ends
+// a line.
+func (t Terminal) write(w io.Writer, content string) {
+	content = t.redactor.Redact(content)
+	if t.stripColors(w) {
+		content = style.Strip(style.StripHyperlinks(content))
+	}
+
+	fmt.Fprint(w, content)
+	t.lines.track(w, content)
+
+	if t.cast != nil {
+		t.cast.record(t.now(), content, t.castSize)
+	}
+}
@@ -0,0 +1,104 @@
+package disgo
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock returns a clock that starts at the given time and
+// advances by one second every time it is called.
+func fakeClock(start time.Time) func() time.Time {
+	now := start.Add(-time.Second)
+	return func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+}
+
+func TestWithPrefix(t *testing.T) {
+	defaultOut := &bytes.Buffer{}
+	errorOut := &bytes.Buffer{}
+
+	term := NewTerminal(WithDefaultOutput(defaultOut), WithErrorOutput(errorOut), WithPrefix("[deploy]"))
+
+	// Only the first part of a line should be prefixed.
+	term.Info("one sentence")
+	term.Infoln(" and its end")
+	term.Info("two\nlines\n")
+	term.Errorln("an error")
+
+	assert.Equal(t, "[deploy] one sentence and its end\n[deploy] two\n[deploy] lines\n", defaultOut.String())
+	assert.Equal(t, "[deploy] an error\n", errorOut.String())
+}
+
+func TestWithTimestamps(t *testing.T) {
+	defaultOut := &bytes.Buffer{}
+
+	term := NewTerminal(WithDefaultOutput(defaultOut), WithTimestamps("15:04:05"))
+	term.clock = fakeClock(time.Date(2019, 1, 1, 12, 0, 0, 0, time.UTC))
+
+	term.Infoln("first")
+	term.Infoln("second")
+
+	assert.Equal(t, "12:00:00 first\n12:00:01 second\n", defaultOut.String())
+}
+
+func TestDecorationDuringStep(t *testing.T) {
+	defaultOut := &bytes.Buffer{}
+
+	term := NewTerminal(WithDefaultOutput(defaultOut), WithTimestamps("15:04:05"), WithPrefix("app"))
+	term.clock = fakeClock(time.Date(2019, 1, 1, 12, 0, 0, 0, time.UTC))
+
+	// Queued outputs should use the time at which they were produced,
+	// and multi-line outputs should be decorated on every line.
+	term.StartStep("Simulated task #1")
+	term.Infoln("25%")
+	term.Infoln("50%\n75%")
+	term.EndStep()
+	term.Infoln("done")
+
+	assert.Equal(t, "12:00:00 app Simulated task #1...ok\n"+
+		"12:00:01 app   > 25%\n"+
+		"12:00:02 app   > 50%\n"+
+		"12:00:02 app     75%\n"+
+		"12:00:04 app done\n", defaultOut.String())
+}
+
+func TestNoDecoration(t *testing.T) {
+	defaultOut := &bytes.Buffer{}
+
+	term := &Terminal{
+		defaultOutput: defaultOut,
+	}
+
+	term.Info("one sentence")
+	term.Infoln(" and its end")
+
+	assert.Equal(t, "one sentence and its end\n", defaultOut.String())
+}
+
+// writerFunc is a writer whose dynamic type can't be used as a map key.
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) {
+	return f(p)
+}
+
+func TestDecorationUnhashableWriter(t *testing.T) {
+	defaultOut := &bytes.Buffer{}
+	writer := writerFunc(defaultOut.Write)
+
+	term := NewTerminal(WithDefaultOutput(writer), WithPrefix("[deploy]"))
+
+	// Lines can't be tracked on such writers, so every output
+	// is decorated, but writing on them must not panic.
+	assert.NotPanics(t, func() {
+		term.Info("one")
+		term.Infoln(" two")
+	})
+
+	assert.Equal(t, "[deploy] one[deploy]  two\n", defaultOut.String())
+}
@@ -0,0 +1,315 @@
+// Package expect provides an expect-style harness to test command-line
+// interfaces end-to-end in a pseudo-terminal, for features that depend on
+// a real terminal, such as prompts and cursor movements. Sessions are only
+// supported on Linux, but the Screen emulator can be used on any platform.
+package expect
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+const (
+	escape = 0x1b
+	bell   = 0x07
+)
+
+// Screen is a minimal terminal emulator, which renders what a program
+// writes on a terminal by interpreting the control characters and ANSI
+// escape sequences that move the cursor and erase parts of the screen.
+// Styles and other escape sequences are ignored.
+//
+// A Screen is safe for concurrent use.
+type Screen struct {
+	mu sync.Mutex
+
+	rows, cols int
+	cells      [][]rune
+
+	row, col           int
+	savedRow, savedCol int
+
+	// Bytes that were written but could not be interpreted yet,
+	// such as incomplete escape sequences or UTF-8 characters.
+	pending []byte
+}
+
+// NewScreen creates a new blank Screen of the given size.
+func NewScreen(rows, cols int) *Screen {
+	s := &Screen{
+		rows: rows,
+		cols: cols,
+	}
+
+	s.cells = make([][]rune, rows)
+	for i := range s.cells {
+		s.cells[i] = blankLine(cols)
+	}
+
+	return s
+}
+
+// Write interprets p as if it was written on the terminal.
+func (s *Screen) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pending = append(s.pending, p...)
+	for len(s.pending) > 0 {
+		consumed := s.interpret(s.pending)
+		if consumed == 0 {
+			break
+		}
+		s.pending = s.pending[consumed:]
+	}
+
+	return len(p), nil
+}
+
+// String returns the text that is currently displayed on the screen,
+// without trailing spaces on each line and without trailing empty lines.
+func (s *Screen) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lines := make([]string, len(s.cells))
+	for i, line := range s.cells {
+		lines[i] = strings.TrimRight(string(line), " ")
+	}
+
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+// Cursor returns the current position of the cursor,
+// starting from zero.
+func (s *Screen) Cursor() (row, col int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.row, s.col
+}
+
+// interpret interprets the first character or escape sequence of p,
+// and returns how many bytes it consumed, or zero if p does not
+// contain a complete character or sequence yet.
+func (s *Screen) interpret(p []byte) int {
+	switch p[0] {
+	case escape:
+		return s.interpretEscape(p)
+	case '\r':
+		s.col = 0
+	case '\n':
+		s.lineFeed()
+	case '\b':
+		if s.col > 0 {
+			s.col--
+		}
+	case '\t':
+		s.col = min((s.col/8+1)*8, s.cols-1)
+	default:
+		if p[0] < 0x20 || p[0] == 0x7f {
+			// Ignore other control characters, such as the bell.
+			return 1
+		}
+
+		if !utf8.FullRune(p) {
+			return 0
+		}
+
+		r, size := utf8.DecodeRune(p)
+		s.put(r)
+		return size
+	}
+
+	return 1
+}
+
+// interpretEscape interprets the escape sequence at the start of p.
+func (s *Screen) interpretEscape(p []byte) int {
+	if len(p) < 2 {
+		return 0
+	}
+
+	switch p[1] {
+	case '[':
+		return s.interpretCSI(p)
+	case ']':
+		// Operating system commands, such as window titles or
+		// hyperlinks, end with a bell or a string terminator.
+		for i := 2; i < len(p); i++ {
+			if p[i] == bell {
+				return i + 1
+			}
+			if p[i] == escape && i+1 < len(p) && p[i+1] == '\\' {
+				return i + 2
+			}
+		}
+		return 0
+	case '7':
+		s.savedRow, s.savedCol = s.row, s.col
+	case '8':
+		s.row, s.col = s.savedRow, s.savedCol
+	}
+
+	return 2
+}
+
+// interpretCSI interprets the control sequence at the start of p.
+func (s *Screen) interpretCSI(p []byte) int {
+	end := -1
+	for i := 2; i < len(p); i++ {
+		if p[i] >= 0x40 && p[i] <= 0x7e {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		return 0
+	}
+
+	params := string(p[2:end])
+	if strings.HasPrefix(params, "?") {
+		// Private modes, such as hiding the cursor, do not
+		// change what is displayed on the screen.
+		return end + 1
+	}
+
+	args := parseParams(params)
+	arg := func(index, def int) int {
+		if index < len(args) && args[index] > 0 {
+			return args[index]
+		}
+		return def
+	}
+
+	switch p[end] {
+	case 'A':
+		s.row = max(s.row-arg(0, 1), 0)
+	case 'B':
+		s.row = min(s.row+arg(0, 1), s.rows-1)
+	case 'C':
+		s.col = min(s.col+arg(0, 1), s.cols-1)
+	case 'D':
+		s.col = max(s.col-arg(0, 1), 0)
+	case 'E':
+		s.row = min(s.row+arg(0, 1), s.rows-1)
+		s.col = 0
+	case 'F':
+		s.row = max(s.row-arg(0, 1), 0)
+		s.col = 0
+	case 'G':
+		s.col = clamp(arg(0, 1)-1, 0, s.cols-1)
+	case 'H', 'f':
+		s.row = clamp(arg(0, 1)-1, 0, s.rows-1)
+		s.col = clamp(arg(1, 1)-1, 0, s.cols-1)
+	case 'J':
+		s.eraseDisplay(arg(0, 0))
+	case 'K':
+		s.eraseLine(arg(0, 0))
+	case 's':
+		s.savedRow, s.savedCol = s.row, s.col
+	case 'u':
+		s.row, s.col = s.savedRow, s.savedCol
+	}
+
+	return end + 1
+}
+
+// put writes a character at the cursor's position, and moves the cursor
+// forward, wrapping to the next line if the end of the line is reached.
+func (s *Screen) put(r rune) {
+	if s.col >= s.cols {
+		s.col = 0
+		s.lineFeed()
+	}
+
+	s.cells[s.row][s.col] = r
+	s.col++
+}
+
+// lineFeed moves the cursor down, scrolling the screen
+// if the cursor is on the last line.
+func (s *Screen) lineFeed() {
+	if s.row < s.rows-1 {
+		s.row++
+		return
+	}
+
+	s.cells = append(s.cells[1:], blankLine(s.cols))
+}
+
+func (s *Screen) eraseDisplay(mode int) {
+	switch mode {
+	case 0:
+		s.eraseLine(0)
+		for row := s.row + 1; row < s.rows; row++ {
+			s.cells[row] = blankLine(s.cols)
+		}
+	case 1:
+		s.eraseLine(1)
+		for row := 0; row < s.row; row++ {
+			s.cells[row] = blankLine(s.cols)
+		}
+	default:
+		for row := range s.cells {
+			s.cells[row] = blankLine(s.cols)
+		}
+	}
+}
+
+func (s *Screen) eraseLine(mode int) {
+	start, end := 0, s.cols
+	switch mode {
+	case 0:
+		start = min(s.col, s.cols)
+	case 1:
+		end = min(s.col+1, s.cols)
+	}
+
+	for col := start; col < end; col++ {
+		s.cells[s.row][col] = ' '
+	}
+}
+
+// parseParams parses the numeric parameters of a control sequence.
+// Missing or invalid parameters are parsed as zero.
+func parseParams(params string) []int {
+	if params == "" {
+		return nil
+	}
+
+	var args []int
+	for _, param := range strings.Split(params, ";") {
+		arg, _ := strconv.Atoi(param)
+		args = append(args, arg)
+	}
+	return args
+}
+
+func blankLine(cols int) []rune {
+	line := make([]rune, cols)
+	for i := range line {
+		line[i] = ' '
+	}
+	return line
+}
+
+func clamp(value, low, high int) int {
+	return max(low, min(value, high))
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,106 @@
+package expect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScreen(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		rows     int
+		cols     int
+		writes   []string
+		expected string
+	}{
+		{
+			desc:     "plain text",
+			rows:     3,
+			cols:     20,
+			writes:   []string{"hello\r\nworld\r\n"},
+			expected: "hello\nworld",
+		},
+		{
+			desc:     "styles are ignored",
+			rows:     3,
+			cols:     20,
+			writes:   []string{"\x1b[32;1mok\x1b[0m"},
+			expected: "ok",
+		},
+		{
+			desc:     "carriage return overwrites",
+			rows:     3,
+			cols:     20,
+			writes:   []string{"Loading...\rDone"},
+			expected: "Doneing...",
+		},
+		{
+			desc:     "erase line",
+			rows:     3,
+			cols:     20,
+			writes:   []string{"Loading...\r\x1b[2KDone"},
+			expected: "Done",
+		},
+		{
+			desc:     "cursor movement",
+			rows:     3,
+			cols:     20,
+			writes:   []string{"one\r\ntwo\r\n\x1b[2A\x1b[5Gupdated"},
+			expected: "one updated\ntwo",
+		},
+		{
+			desc:     "absolute position and erase display",
+			rows:     3,
+			cols:     20,
+			writes:   []string{"one\r\ntwo\r\nthree", "\x1b[2;1H\x1b[J", "new"},
+			expected: "one\nnew",
+		},
+		{
+			desc:     "scrolling",
+			rows:     2,
+			cols:     20,
+			writes:   []string{"one\r\ntwo\r\nthree"},
+			expected: "two\nthree",
+		},
+		{
+			desc:     "wrapping",
+			rows:     3,
+			cols:     5,
+			writes:   []string{"abcdefgh"},
+			expected: "abcde\nfgh",
+		},
+		{
+			desc:     "split escape sequences and characters",
+			rows:     3,
+			cols:     20,
+			writes:   []string{"\x1b[3", "2mo", "k \xe2\x9c", "\x94"},
+			expected: "ok ✔",
+		},
+		{
+			desc:     "hyperlinks and private modes",
+			rows:     3,
+			cols:     20,
+			writes:   []string{"\x1b[?25l\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\\x1b[?25h"},
+			expected: "link",
+		},
+		{
+			desc:     "backspace",
+			rows:     3,
+			cols:     20,
+			writes:   []string{"abc\b\bX"},
+			expected: "aXc",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			screen := NewScreen(test.rows, test.cols)
+			for _, write := range test.writes {
+				screen.Write([]byte(write))
+			}
+
+			assert.Equal(t, test.expected, screen.String())
+		})
+	}
+}
@@ -0,0 +1,282 @@
+//go:build linux
+// +build linux
+
+package expect
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Ullaakut/disgo"
+	"github.com/creack/pty"
+)
+
+// Keys that can be sent to a session, as a terminal would send them.
+const (
+	KeyEnter     = "\r"
+	KeyTab       = "\t"
+	KeyBackspace = "\x7f"
+	KeyEscape    = "\x1b"
+	KeyUp        = "\x1b[A"
+	KeyDown      = "\x1b[B"
+	KeyRight     = "\x1b[C"
+	KeyLeft      = "\x1b[D"
+	KeyCtrlC     = "\x03"
+	KeyCtrlD     = "\x04"
+)
+
+// ErrTimeout is returned when an expected text does not
+// appear on the screen in time.
+var ErrTimeout = errors.New("timed out waiting for expected text")
+
+// Session is a function or a program running attached to a pseudo-terminal,
+// which can be sent keystrokes and whose screen can be inspected.
+type Session struct {
+	rows, cols  int
+	timeout     time.Duration
+	termOptions []func(*disgo.Terminal)
+
+	ptmx   *os.File
+	screen *Screen
+
+	mu       sync.Mutex
+	output   bytes.Buffer
+	updated  chan struct{}
+	readDone chan struct{}
+
+	done chan struct{}
+	err  error
+
+	closeOnce sync.Once
+	closeFunc func()
+}
+
+// WithSize sets the size of the pseudo-terminal, which is 24 rows
+// of 80 columns by default.
+func WithSize(rows, cols int) func(*Session) {
+	return func(s *Session) {
+		s.rows = rows
+		s.cols = cols
+	}
+}
+
+// WithTimeout sets how long Expect waits for a text to appear
+// on the screen, which is 5 seconds by default.
+func WithTimeout(timeout time.Duration) func(*Session) {
+	return func(s *Session) {
+		s.timeout = timeout
+	}
+}
+
+// WithTerminalOptions sets options to apply on the disgo Terminal
+// that StartFunc gives to its function, after the session's own.
+func WithTerminalOptions(options ...func(*disgo.Terminal)) func(*Session) {
+	return func(s *Session) {
+		s.termOptions = append(s.termOptions, options...)
+	}
+}
+
+func newSession(options []func(*Session)) *Session {
+	s := &Session{
+		rows:     24,
+		cols:     80,
+		timeout:  5 * time.Second,
+		updated:  make(chan struct{}),
+		readDone: make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	for _, option := range options {
+		option(s)
+	}
+
+	s.screen = NewScreen(s.rows, s.cols)
+	return s
+}
+
+// StartCommand starts the given command attached to a new pseudo-terminal,
+// which becomes its controlling terminal. Sending KeyCtrlC to the session
+// interrupts the command, like it would in a real terminal.
+func StartCommand(cmd *exec.Cmd, options ...func(*Session)) (*Session, error) {
+	s := newSession(options)
+
+	ptmx, err := pty.StartWithSize(cmd, s.winsize())
+	if err != nil {
+		return nil, fmt.Errorf("unable to start command in a pseudo-terminal: %v", err)
+	}
+	s.ptmx = ptmx
+	s.closeFunc = func() {
+		select {
+		case <-s.done:
+		default:
+			_ = cmd.Process.Kill()
+		}
+	}
+
+	go s.read()
+	go func() {
+		s.finish(cmd.Wait())
+	}()
+
+	return s, nil
+}
+
+// StartFunc runs the given function in a goroutine, with a disgo Terminal
+// whose writers and reader are attached to a new pseudo-terminal. Since the
+// function does not run in its own process, sending KeyCtrlC does not
+// interrupt it.
+func StartFunc(fn func(term *disgo.Terminal) error, options ...func(*Session)) (*Session, error) {
+	s := newSession(options)
+
+	ptmx, tty, err := pty.Open()
+	if err != nil {
+		return nil, fmt.Errorf("unable to open pseudo-terminal: %v", err)
+	}
+	if err := pty.Setsize(ptmx, s.winsize()); err != nil {
+		ptmx.Close()
+		tty.Close()
+		return nil, fmt.Errorf("unable to set pseudo-terminal size: %v", err)
+	}
+	s.ptmx = ptmx
+	s.closeFunc = func() {
+		tty.Close()
+	}
+
+	defaults := []func(*disgo.Terminal){
+		disgo.WithDefaultOutput(tty),
+		disgo.WithErrorOutput(tty),
+		disgo.WithReader(tty),
+		disgo.WithInteractive(true),
+	}
+	term := disgo.NewTerminal(append(defaults, s.termOptions...)...)
+
+	go s.read()
+	go func() {
+		s.finish(fn(term))
+	}()
+
+	return s, nil
+}
+
+func (s *Session) winsize() *pty.Winsize {
+	return &pty.Winsize{
+		Rows: uint16(s.rows),
+		Cols: uint16(s.cols),
+	}
+}
+
+// read copies everything that is written on the pseudo-terminal
+// to the screen, until it is closed.
+func (s *Session) read() {
+	defer close(s.readDone)
+
+	buf := make([]byte, 4096)
+	for {
+		n, err := s.ptmx.Read(buf)
+		if n > 0 {
+			s.screen.Write(buf[:n])
+
+			s.mu.Lock()
+			s.output.Write(buf[:n])
+			close(s.updated)
+			s.updated = make(chan struct{})
+			s.mu.Unlock()
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (s *Session) finish(err error) {
+	s.err = err
+	close(s.done)
+}
+
+// Send sends the given keys to the session, as if the user typed them.
+func (s *Session) Send(keys ...string) error {
+	for _, key := range keys {
+		if _, err := s.ptmx.Write([]byte(key)); err != nil {
+			return fmt.Errorf("unable to send keys: %v", err)
+		}
+	}
+	return nil
+}
+
+// SendLine sends the given text to the session, followed by KeyEnter.
+func (s *Session) SendLine(text string) error {
+	return s.Send(text, KeyEnter)
+}
+
+// Expect waits until the given text appears on the screen, and returns
+// ErrTimeout if it does not appear before the session's timeout.
+func (s *Session) Expect(text string) error {
+	timeout := time.After(s.timeout)
+	for {
+		s.mu.Lock()
+		updated := s.updated
+		s.mu.Unlock()
+
+		if strings.Contains(s.screen.String(), text) {
+			return nil
+		}
+
+		select {
+		case <-updated:
+		case <-timeout:
+			return fmt.Errorf("%w %q, screen is:\n%s", ErrTimeout, text, s.screen.String())
+		}
+	}
+}
+
+// Screen returns the text that is currently displayed on the
+// session's screen, after interpreting ANSI escape sequences.
+func (s *Session) Screen() string {
+	return s.screen.String()
+}
+
+// Output returns everything that was written on the
+// pseudo-terminal so far, as is.
+func (s *Session) Output() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.output.String()
+}
+
+// Wait waits for the function or command to return, and returns
+// its error. It returns ErrTimeout if it does not return before
+// the session's timeout.
+func (s *Session) Wait() error {
+	select {
+	case <-s.done:
+	case <-time.After(s.timeout):
+		return ErrTimeout
+	}
+
+	// Give the reader a chance to render what was
+	// written right before the session ended.
+	select {
+	case <-s.readDone:
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	return s.err
+}
+
+// Close kills the command if it is still running, and
+// closes the pseudo-terminal.
+func (s *Session) Close() error {
+	var err error
+	s.closeOnce.Do(func() {
+		s.closeFunc()
+		err = s.ptmx.Close()
+	})
+	return err
+}
@@ -0,0 +1,78 @@
+//go:build linux
+// +build linux
+
+package expect
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ullaakut/disgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartFunc(t *testing.T) {
+	session, err := StartFunc(func(term *disgo.Terminal) error {
+		term.StartStep("Preparing")
+		term.EndStep()
+
+		ok, err := term.Confirm(disgo.Confirmation{Label: "Continue?"})
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errors.New("cancelled")
+		}
+
+		term.Infoln("Continuing")
+		return nil
+	}, WithTerminalOptions(disgo.WithColors(false)))
+	require.NoError(t, err)
+	defer session.Close()
+
+	require.NoError(t, session.Expect("Continue? [y/n]"))
+	require.NoError(t, session.SendLine("y"))
+	require.NoError(t, session.Expect("Continuing"))
+	require.NoError(t, session.Wait())
+
+	// The answer should be echoed by the pseudo-terminal.
+	assert.Equal(t, "Preparing...ok\nContinue? [y/n] y\nContinuing", session.Screen())
+}
+
+func TestStartCommand(t *testing.T) {
+	session, err := StartCommand(exec.Command("sh", "-c", `printf 'Name? '; read name; printf '\033[1A\033[2Khello %s\n' "$name"`), WithSize(10, 40))
+	require.NoError(t, err)
+	defer session.Close()
+
+	require.NoError(t, session.Expect("Name?"))
+	require.NoError(t, session.SendLine("gopher"))
+	require.NoError(t, session.Wait())
+
+	// The prompt line should have been erased by the command.
+	assert.Equal(t, "hello gopher", strings.TrimSpace(session.Screen()))
+}
+
+func TestStartCommandInterrupt(t *testing.T) {
+	session, err := StartCommand(exec.Command("sh", "-c", "echo ready; sleep 10"))
+	require.NoError(t, err)
+	defer session.Close()
+
+	require.NoError(t, session.Expect("ready"))
+	require.NoError(t, session.Send(KeyCtrlC))
+
+	assert.Error(t, session.Wait())
+}
+
+func TestExpectTimeout(t *testing.T) {
+	session, err := StartCommand(exec.Command("sh", "-c", "echo something; sleep 1"), WithTimeout(100*time.Millisecond))
+	require.NoError(t, err)
+	defer session.Close()
+
+	err = session.Expect("something else")
+	assert.True(t, errors.Is(err, ErrTimeout))
+	assert.Contains(t, err.Error(), "something")
+}
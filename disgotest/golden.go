@@ -0,0 +1,160 @@
+package disgotest
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// update is namespaced, so that it does not conflict with the `-update`
+// flag that many packages define for their own golden files.
+var update = flag.Bool("disgotest.update", false, "update the golden files of disgotest.AssertGolden")
+
+// goldenDir is the directory in which golden files are stored,
+// relative to the directory of the package under test.
+var goldenDir = "testdata"
+
+// Normalizer replaces the volatile parts of a transcript, such as
+// timestamps, so that it can be compared with a golden file.
+type Normalizer func(transcript string) string
+
+var (
+	timestampPattern = regexp.MustCompile(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(?:\.\d+)?(?:Z|[+-]\d{2}:?\d{2})?|\b\d{2}:\d{2}:\d{2}(?:\.\d+)?\b`)
+	durationPattern  = regexp.MustCompile(`\b(?:\d+(?:\.\d+)?(?:ns|us|µs|ms|s|m|h))+\b`)
+)
+
+// NormalizeTimestamps replaces RFC 3339 timestamps and times of
+// day such as `15:04:05` with `<timestamp>`.
+func NormalizeTimestamps(transcript string) string {
+	return timestampPattern.ReplaceAllString(transcript, "<timestamp>")
+}
+
+// NormalizeDurations replaces durations as formatted by
+// time.Duration, such as `1.5s` or `1m30s`, with `<duration>`.
+func NormalizeDurations(transcript string) string {
+	return durationPattern.ReplaceAllString(transcript, "<duration>")
+}
+
+// NormalizeRedraws keeps only the last version of lines that were
+// redrawn using carriage returns, such as the frames of a spinner
+// or of a progress bar.
+func NormalizeRedraws(transcript string) string {
+	lines := strings.Split(transcript, "\n")
+	for i, line := range lines {
+		// Ignore a trailing carriage return, which is
+		// part of a Windows line ending.
+		line = strings.TrimSuffix(line, "\r")
+		if index := strings.LastIndex(line, "\r"); index >= 0 {
+			line = line[index+1:]
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// DefaultNormalizers are the normalizers that AssertGolden uses
+// when none are given.
+var DefaultNormalizers = []Normalizer{
+	NormalizeRedraws,
+	NormalizeTimestamps,
+	NormalizeDurations,
+}
+
+// AssertGolden runs the given function against a new test terminal, and
+// compares the transcripts of its default and error outputs, without ANSI
+// escape sequences, with the golden files `<name>.stdout.golden` and
+// `<name>.stderr.golden` in the testdata directory. Transcripts are first
+// normalized with the given normalizers, or with DefaultNormalizers if
+// none are given.
+//
+// When tests are run with the `-disgotest.update` flag, the golden
+// files are written instead of being compared.
+func AssertGolden(tb testing.TB, name string, run func(term *Terminal), normalizers ...Normalizer) bool {
+	tb.Helper()
+
+	if len(normalizers) == 0 {
+		normalizers = DefaultNormalizers
+	}
+
+	term := NewTestTerminal()
+	run(term)
+
+	stdout := normalize(term.Output(), normalizers)
+	stderr := normalize(term.ErrorOutput(), normalizers)
+
+	stdoutOK := compareGolden(tb, name+".stdout.golden", stdout)
+	stderrOK := compareGolden(tb, name+".stderr.golden", stderr)
+	return stdoutOK && stderrOK
+}
+
+func normalize(transcript string, normalizers []Normalizer) string {
+	for _, normalizer := range normalizers {
+		transcript = normalizer(transcript)
+	}
+	return transcript
+}
+
+// compareGolden compares a transcript with a golden file, or writes
+// the transcript to the golden file if the update flag is set.
+func compareGolden(tb testing.TB, file, actual string) bool {
+	tb.Helper()
+
+	path := filepath.Join(goldenDir, file)
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			tb.Errorf("unable to create golden file directory: %v", err)
+			return false
+		}
+		if err := ioutil.WriteFile(path, []byte(actual), 0644); err != nil {
+			tb.Errorf("unable to update golden file: %v", err)
+			return false
+		}
+		return true
+	}
+
+	expected, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		tb.Errorf("golden file %s does not exist, run the tests with -disgotest.update to create it", path)
+		return false
+	}
+	if err != nil {
+		tb.Errorf("unable to read golden file: %v", err)
+		return false
+	}
+
+	if string(expected) != actual {
+		tb.Errorf("transcript does not match golden file %s, run the tests with -disgotest.update to update it\n%s", path, goldenMismatch(string(expected), actual))
+		return false
+	}
+
+	return true
+}
+
+// goldenMismatch describes the first line on which a transcript
+// differs from its golden file.
+func goldenMismatch(expected, actual string) string {
+	expectedLines := strings.Split(expected, "\n")
+	actualLines := strings.Split(actual, "\n")
+
+	for i := 0; i < len(expectedLines) || i < len(actualLines); i++ {
+		var expectedLine, actualLine string
+		if i < len(expectedLines) {
+			expectedLine = expectedLines[i]
+		}
+		if i < len(actualLines) {
+			actualLine = actualLines[i]
+		}
+
+		if expectedLine != actualLine {
+			return fmt.Sprintf("line %d:\n  expected: %q\n  actual:   %q", i+1, expectedLine, actualLine)
+		}
+	}
+
+	return ""
+}
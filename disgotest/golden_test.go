@@ -0,0 +1,135 @@
+package disgotest
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Packages that use disgotest commonly define their own update flag.
+var _ = flag.Bool("update", false, "update the golden files of the package")
+
+func TestNormalizers(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		normalizer Normalizer
+		transcript string
+		expected   string
+	}{
+		{
+			desc:       "rfc3339 timestamp",
+			normalizer: NormalizeTimestamps,
+			transcript: "2019-01-01T12:00:00.123Z INFO started",
+			expected:   "<timestamp> INFO started",
+		},
+		{
+			desc:       "time of day",
+			normalizer: NormalizeTimestamps,
+			transcript: "[15:04:05] started",
+			expected:   "[<timestamp>] started",
+		},
+		{
+			desc:       "durations",
+			normalizer: NormalizeDurations,
+			transcript: "took 1.5s, then 1m30s and 20ms",
+			expected:   "took <duration>, then <duration> and <duration>",
+		},
+		{
+			desc:       "durations in words are left untouched",
+			normalizer: NormalizeDurations,
+			transcript: "v2s is not a duration",
+			expected:   "v2s is not a duration",
+		},
+		{
+			desc:       "redraws",
+			normalizer: NormalizeRedraws,
+			transcript: "Loading |\rLoading /\rLoading -\rLoaded\r\nDone\n",
+			expected:   "Loaded\nDone\n",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.normalizer(test.transcript))
+		})
+	}
+}
+
+func runInstall(term *Terminal) {
+	term.Answer("n")
+
+	start := time.Now()
+	_ = install(term.Terminal)
+	term.Infoln("Finished in", time.Since(start))
+}
+
+func TestAssertGolden(t *testing.T) {
+	AssertGolden(t, "install", runInstall)
+}
+
+func TestAssertGoldenUpdate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "disgotest")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	previousDir := goldenDir
+	goldenDir = dir
+	defer func() {
+		goldenDir = previousDir
+	}()
+
+	// Without golden files, the assertion should fail.
+	tb := &fakeTB{}
+	assert.False(t, AssertGolden(tb, "install", runInstall))
+	require.Len(t, tb.failures, 2)
+	assert.Contains(t, tb.failures[0], "-disgotest.update")
+
+	*update = true
+	assert.True(t, AssertGolden(t, "install", runInstall))
+	*update = false
+
+	assert.True(t, AssertGolden(t, "install", runInstall))
+
+	content, err := ioutil.ReadFile(filepath.Join(dir, "install.stdout.golden"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "Finished in <duration>")
+
+	// A different transcript should not match the golden files.
+	tb = &fakeTB{}
+	assert.False(t, AssertGolden(tb, "install", func(term *Terminal) {
+		term.Answer("y")
+		_ = install(term.Terminal)
+	}))
+	require.Len(t, tb.failures, 2)
+	assert.Contains(t, tb.failures[0], "line 3")
+}
+
+func TestAssertGoldenCustomNormalizers(t *testing.T) {
+	dir, err := ioutil.TempDir("", "disgotest")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	previousDir := goldenDir
+	goldenDir = dir
+	defer func() {
+		goldenDir = previousDir
+	}()
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "custom.stdout.golden"), []byte("Running as process <pid>\n"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "custom.stderr.golden"), nil, 0644))
+
+	pidPattern := regexp.MustCompile(`process \d+`)
+
+	AssertGolden(t, "custom", func(term *Terminal) {
+		term.Infoln("Running as process", os.Getpid())
+	}, func(transcript string) string {
+		return pidPattern.ReplaceAllString(transcript, "process <pid>")
+	})
+}
@@ -0,0 +1,222 @@
+// Package disgotest provides utilities for testing command-line
+// interfaces built with disgo, without having to plumb buffers
+// into terminals or to match ANSI escape sequences.
+package disgotest
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Ullaakut/disgo"
+	"github.com/Ullaakut/disgo/style"
+)
+
+// Terminal is a disgo Terminal that writes to memory, reads the
+// answers to its prompts from a script, and records all of its events.
+// It embeds the disgo Terminal that should be given to the code under test.
+type Terminal struct {
+	*disgo.Terminal
+
+	output      *buffer
+	errorOutput *buffer
+	answers     *buffer
+
+	mu     sync.Mutex
+	events []disgo.Event
+}
+
+// NewTestTerminal creates a new test Terminal. The given options are
+// applied after the test terminal's own, so they can be used to enable
+// debug outputs for example. Replacing the terminal's writers or reader
+// is not supported.
+func NewTestTerminal(options ...func(*disgo.Terminal)) *Terminal {
+	term := &Terminal{
+		output:      &buffer{},
+		errorOutput: &buffer{},
+		answers:     &buffer{},
+	}
+
+	defaults := []func(*disgo.Terminal){
+		disgo.WithDefaultOutput(term.output),
+		disgo.WithErrorOutput(term.errorOutput),
+		disgo.WithReader(term.answers),
+		disgo.WithInteractive(true),
+		disgo.WithEventHandler(term.record),
+	}
+
+	term.Terminal = disgo.NewTerminal(append(defaults, options...)...)
+	return term
+}
+
+// Answer scripts answers to the next prompts, in order. Each answer
+// is given as if the user typed it and pressed enter. If a prompt is
+// reached while no answer is scripted, it returns an error.
+func (t *Terminal) Answer(answers ...string) {
+	for _, answer := range answers {
+		t.answers.Write([]byte(answer + "\n"))
+	}
+}
+
+// Output returns everything that was written on the terminal's
+// default writer, without ANSI escape sequences.
+func (t *Terminal) Output() string {
+	return style.Strip(t.RawOutput())
+}
+
+// RawOutput returns everything that was written on the terminal's
+// default writer, as is.
+func (t *Terminal) RawOutput() string {
+	return t.output.String()
+}
+
+// ErrorOutput returns everything that was written on the terminal's
+// error writer, without ANSI escape sequences.
+func (t *Terminal) ErrorOutput() string {
+	return style.Strip(t.RawErrorOutput())
+}
+
+// RawErrorOutput returns everything that was written on the terminal's
+// error writer, as is.
+func (t *Terminal) RawErrorOutput() string {
+	return t.errorOutput.String()
+}
+
+// Events returns all of the events that happened on the terminal
+// so far, in order.
+func (t *Terminal) Events() []disgo.Event {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	events := make([]disgo.Event, len(t.events))
+	copy(events, t.events)
+	return events
+}
+
+// Outputs returns the text of all output events of the given
+// level, without ANSI escape sequences.
+func (t *Terminal) Outputs(level disgo.Level) []string {
+	var outputs []string
+	for _, event := range t.Events() {
+		if event.Kind == disgo.EventOutput && event.Level == level {
+			outputs = append(outputs, style.Strip(event.Text))
+		}
+	}
+	return outputs
+}
+
+// AssertOutputContains checks that the terminal's default writer
+// received the given text, ignoring ANSI escape sequences.
+func (t *Terminal) AssertOutputContains(tb testing.TB, text string) bool {
+	tb.Helper()
+
+	if !strings.Contains(t.Output(), text) {
+		tb.Errorf("expected output to contain %q, got:\n%s", text, t.Output())
+		return false
+	}
+	return true
+}
+
+// AssertErrorOutputContains checks that the terminal's error writer
+// received the given text, ignoring ANSI escape sequences.
+func (t *Terminal) AssertErrorOutputContains(tb testing.TB, text string) bool {
+	tb.Helper()
+
+	if !strings.Contains(t.ErrorOutput(), text) {
+		tb.Errorf("expected error output to contain %q, got:\n%s", text, t.ErrorOutput())
+		return false
+	}
+	return true
+}
+
+// AssertNoErrorOutput checks that nothing was written on the
+// terminal's error writer.
+func (t *Terminal) AssertNoErrorOutput(tb testing.TB) bool {
+	tb.Helper()
+
+	if output := t.ErrorOutput(); output != "" {
+		tb.Errorf("expected no error output, got:\n%s", output)
+		return false
+	}
+	return true
+}
+
+// AssertStepSucceeded checks that a step with the given label
+// was started and then ended successfully.
+func (t *Terminal) AssertStepSucceeded(tb testing.TB, label string) bool {
+	tb.Helper()
+
+	return t.assertStepResult(tb, label, disgo.EventStepEnded)
+}
+
+// AssertStepFailed checks that a step with the given label
+// was started and then ended with a failure.
+func (t *Terminal) AssertStepFailed(tb testing.TB, label string) bool {
+	tb.Helper()
+
+	return t.assertStepResult(tb, label, disgo.EventStepFailed)
+}
+
+func (t *Terminal) assertStepResult(tb testing.TB, label string, expected disgo.EventKind) bool {
+	tb.Helper()
+
+	var started bool
+	for _, event := range t.Events() {
+		if event.Text != label {
+			continue
+		}
+
+		switch event.Kind {
+		case disgo.EventStepStarted:
+			started = true
+		case disgo.EventStepEnded, disgo.EventStepFailed:
+			if event.Kind != expected {
+				tb.Errorf("expected step %q to end with %s, but it ended with %s", label, expected, event.Kind)
+				return false
+			}
+			return true
+		}
+	}
+
+	if started {
+		tb.Errorf("expected step %q to end with %s, but it never ended", label, expected)
+	} else {
+		tb.Errorf("expected step %q to end with %s, but it never started", label, expected)
+	}
+	return false
+}
+
+func (t *Terminal) record(event disgo.Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.events = append(t.events, event)
+}
+
+// buffer is a bytes.Buffer that is safe for concurrent use.
+type buffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *buffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *buffer) Read(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Read(p)
+}
+
+func (b *buffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.String()
+}
@@ -0,0 +1,108 @@
+package disgotest
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Ullaakut/disgo"
+	"github.com/Ullaakut/disgo/style"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTB records the failures reported by assertion helpers,
+// so that they can be tested.
+type fakeTB struct {
+	testing.TB
+
+	failures []string
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Errorf(format string, args ...interface{}) {
+	f.failures = append(f.failures, fmt.Sprintf(format, args...))
+}
+
+func install(term *disgo.Terminal) error {
+	term.Infoln("Looking for", style.Link("database"))
+
+	term.StartStep("Accessing database")
+	term.Debugln("connecting")
+	term.EndStep()
+
+	ok, err := term.Confirm(disgo.Confirmation{Label: "Install?"})
+	if err != nil {
+		return err
+	}
+
+	term.StartStep("Installing")
+	if !ok {
+		term.Errorln("cancelled by user")
+		return term.FailStep(errors.New("installation cancelled"))
+	}
+	term.EndStep()
+
+	return nil
+}
+
+func TestTerminalSuccess(t *testing.T) {
+	term := NewTestTerminal(disgo.WithDebug(true))
+	term.Answer("y")
+
+	require.NoError(t, install(term.Terminal))
+
+	term.AssertOutputContains(t, "Looking for database")
+	term.AssertOutputContains(t, "Install? [y/n] ")
+	term.AssertStepSucceeded(t, "Accessing database")
+	term.AssertStepSucceeded(t, "Installing")
+	term.AssertNoErrorOutput(t)
+
+	assert.Equal(t, []string{"connecting\n"}, term.Outputs(disgo.LevelDebug))
+
+	events := term.Events()
+	require.Len(t, events, 7)
+	assert.Equal(t, disgo.EventOutput, events[2].Kind)
+	assert.Equal(t, "Accessing database", events[2].Step)
+	assert.Equal(t, disgo.EventPrompt, events[4].Kind)
+	assert.Equal(t, "y", events[4].Answer)
+}
+
+func TestTerminalFailure(t *testing.T) {
+	term := NewTestTerminal()
+	term.Answer("n")
+
+	require.Error(t, install(term.Terminal))
+
+	term.AssertStepFailed(t, "Installing")
+	term.AssertErrorOutputContains(t, "cancelled by user")
+
+	// The raw outputs should still contain the ANSI escape sequences.
+	assert.Contains(t, term.RawErrorOutput(), "cancelled by user")
+	assert.Empty(t, term.Outputs(disgo.LevelDebug))
+}
+
+func TestTerminalMissingAnswer(t *testing.T) {
+	term := NewTestTerminal()
+
+	assert.Error(t, install(term.Terminal))
+}
+
+func TestAssertionFailures(t *testing.T) {
+	term := NewTestTerminal()
+	term.Answer("n")
+	_ = install(term.Terminal)
+
+	tb := &fakeTB{}
+
+	assert.False(t, term.AssertOutputContains(tb, "not there"))
+	assert.False(t, term.AssertErrorOutputContains(tb, "not there"))
+	assert.False(t, term.AssertNoErrorOutput(tb))
+	assert.False(t, term.AssertStepFailed(tb, "Accessing database"))
+	assert.False(t, term.AssertStepSucceeded(tb, "Installing"))
+	assert.False(t, term.AssertStepSucceeded(tb, "Unknown step"))
+
+	require.Len(t, tb.failures, 6)
+	assert.Contains(t, tb.failures[5], "never started")
+}
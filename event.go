@@ -0,0 +1,96 @@
+package disgo
+
+import "time"
+
+// EventKind represents the kind of an Event.
+type EventKind int
+
+const (
+	// EventOutput is emitted when an output is produced and is not
+	// discarded, which means that debug outputs are not emitted when
+	// debug is disabled. Outputs that are queued during a step are
+	// emitted when they are produced, not when the step ends.
+	EventOutput EventKind = iota
+	// EventStepStarted is emitted when a step is started.
+	EventStepStarted
+	// EventStepEnded is emitted when a step is ended successfully.
+	EventStepEnded
+	// EventStepFailed is emitted when a step is ended with a failure.
+	EventStepFailed
+	// EventPrompt is emitted when the user answers a prompt.
+	EventPrompt
+)
+
+// String returns the name of the event kind.
+func (k EventKind) String() string {
+	switch k {
+	case EventOutput:
+		return "output"
+	case EventStepStarted:
+		return "step_started"
+	case EventStepEnded:
+		return "step_ended"
+	case EventStepFailed:
+		return "step_failed"
+	case EventPrompt:
+		return "prompt"
+	}
+	return "unknown"
+}
+
+// Event describes something that happened on a terminal. Secrets
+// are masked in all of its fields, but styles are kept as is.
+type Event struct {
+	// Kind of event.
+	Kind EventKind
+	// Time at which the event happened.
+	Time time.Time
+
+	// Level of the output, for EventOutput events.
+	Level Level
+	// Text is the content of the output for EventOutput events, the
+	// label of the step for step events, and the label of the prompt
+	// for EventPrompt events.
+	Text string
+	// Step is the label of the step that was in progress when the
+	// event happened, if there was one.
+	Step string
+
+	// Answer is the user's raw answer, for EventPrompt events.
+	Answer string
+	// Err is the error with which a step failed, for
+	// EventStepFailed events. It can be nil.
+	Err error
+}
+
+// WithEventHandler adds a function that is called with every event
+// that happens on the terminal, which makes it possible to record
+// what a terminal does. Handlers are called synchronously, in the
+// order in which they were added. Child terminals inherit their
+// parent's handlers.
+func WithEventHandler(handler func(Event)) func(*Terminal) {
+	return func(term *Terminal) {
+		// Use a full slice expression so that child terminals never
+		// share the backing array of their parent's handlers.
+		term.handlers = append(term.handlers[:len(term.handlers):len(term.handlers)], handler)
+	}
+}
+
+// emit sends an event to the terminal's handlers, after setting
+// its time and step, and masking its secrets.
+func (t Terminal) emit(event Event) {
+	if len(t.handlers) == 0 {
+		return
+	}
+
+	event.Time = t.now()
+	event.Text = t.redactor.Redact(event.Text)
+	event.Answer = t.redactor.Redact(event.Answer)
+	if s := t.currentStep(); s != nil {
+		event.Step = s.label
+	}
+
+	for _, handler := range t.handlers {
+		handler(event)
+	}
+}
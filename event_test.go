@@ -0,0 +1,76 @@
+package disgo
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithEventHandler(t *testing.T) {
+	var events []Event
+
+	term := NewTerminal(
+		WithDefaultOutput(&bytes.Buffer{}),
+		WithErrorOutput(&bytes.Buffer{}),
+		WithReader(strings.NewReader("yes\n")),
+		WithEventHandler(func(event Event) {
+			events = append(events, event)
+		}),
+	)
+	term.RegisterSecret("hunter2")
+
+	dummyError := errors.New("dummy error")
+
+	term.Infoln("before")
+	term.Debugln("hidden")
+	term.StartStep("Simulated task #1")
+	term.Errorln("using hunter2")
+	_ = term.FailStep(dummyError)
+	_, err := term.Confirm(Confirmation{Label: "Continue?"})
+	require.NoError(t, err)
+
+	require.Len(t, events, 5)
+
+	assert.Equal(t, EventOutput, events[0].Kind)
+	assert.Equal(t, LevelInfo, events[0].Level)
+	assert.Equal(t, "before\n", events[0].Text)
+	assert.Empty(t, events[0].Step)
+	assert.False(t, events[0].Time.IsZero())
+
+	assert.Equal(t, EventStepStarted, events[1].Kind)
+	assert.Equal(t, "Simulated task #1", events[1].Text)
+
+	assert.Equal(t, EventOutput, events[2].Kind)
+	assert.Equal(t, LevelError, events[2].Level)
+	assert.Equal(t, "using ****\n", events[2].Text)
+	assert.Equal(t, "Simulated task #1", events[2].Step)
+
+	assert.Equal(t, EventStepFailed, events[3].Kind)
+	assert.Equal(t, "Simulated task #1", events[3].Text)
+	assert.Equal(t, dummyError, events[3].Err)
+
+	assert.Equal(t, EventPrompt, events[4].Kind)
+	assert.Equal(t, "Continue?", events[4].Text)
+	assert.Equal(t, "yes", events[4].Answer)
+	assert.Empty(t, events[4].Step)
+}
+
+func TestEventHandlersAreNotSharedWithParent(t *testing.T) {
+	var parentEvents, childEvents int
+
+	parent := NewTerminal(
+		WithDefaultOutput(&bytes.Buffer{}),
+		WithEventHandler(func(Event) { parentEvents++ }),
+	)
+	child := parent.With(WithEventHandler(func(Event) { childEvents++ }))
+
+	parent.Infoln("from parent")
+	child.Infoln("from child")
+
+	assert.Equal(t, 2, parentEvents)
+	assert.Equal(t, 1, childEvents)
+}
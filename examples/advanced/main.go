@@ -30,13 +30,16 @@ import (
 func main() {
 	term := disgo.NewTerminal(disgo.WithDebug(true))
 
+	// The theme's symbols are ASCII ones on terminals that can't display UTF-8.
+	theme := term.Theme()
+
 	if err := install(term); err != nil {
 		term.Errorln(style.Failure(err))
-		term.Infoln(style.Failure(style.SymbolCross), "Installation failed")
+		term.Infoln(theme.Failure.Sprint(theme.Symbols.Cross), "Installation failed")
 		os.Exit(1)
 	}
 
-	term.Infoln(style.Success(style.SymbolCheck), "Installation successful")
+	term.Infoln(theme.Success.Sprint(theme.Symbols.Check), "Installation successful")
 }
 
 func install(term *disgo.Terminal) error {
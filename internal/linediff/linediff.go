@@ -0,0 +1,170 @@
+// Package linediff computes the differences between two sequences of
+// lines, for the diffs that disgo renders.
+package linediff
+
+// Kind is the kind of an operation of a diff.
+type Kind int
+
+// Kinds of operations.
+const (
+	// Equal is a line that is in both sequences.
+	Equal Kind = iota
+	// Delete is a line that is only in the first sequence.
+	Delete
+	// Insert is a line that is only in the second sequence.
+	Insert
+)
+
+// Op is a line of a diff. A and B are the indexes of the line in each
+// sequence, and -1 if the line is not in the corresponding sequence.
+type Op struct {
+	Kind Kind
+	A, B int
+}
+
+// Lines returns the shortest list of operations that turns a into b,
+// computed with Myers' algorithm in linear space. Around each change,
+// deleted lines come before inserted ones.
+func Lines(a, b []string) []Op {
+	// Lines are compared as integers, which is faster
+	// than comparing strings over and over.
+	ids := make(map[string]int)
+	intern := func(lines []string) []int {
+		interned := make([]int, len(lines))
+		for i, line := range lines {
+			id, ok := ids[line]
+			if !ok {
+				id = len(ids)
+				ids[line] = id
+			}
+			interned[i] = id
+		}
+		return interned
+	}
+
+	d := differ{
+		a:       intern(a),
+		b:       intern(b),
+		deleted: make([]bool, len(a)),
+		added:   make([]bool, len(b)),
+	}
+	d.compare(0, len(a), 0, len(b))
+
+	ops := make([]Op, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && d.deleted[i]:
+			ops = append(ops, Op{Delete, i, -1})
+			i++
+		case j < len(b) && d.added[j]:
+			ops = append(ops, Op{Insert, -1, j})
+			j++
+		default:
+			ops = append(ops, Op{Equal, i, j})
+			i++
+			j++
+		}
+	}
+	return ops
+}
+
+// differ marks the lines of a that are deleted
+// and the lines of b that are added.
+type differ struct {
+	a, b           []int
+	deleted, added []bool
+}
+
+// compare marks the changes between a[aLo:aHi] and b[bLo:bHi], by
+// splitting them around the middle snake of their shortest edit script
+// and comparing both halves, until one of the ranges is empty.
+func (d *differ) compare(aLo, aHi, bLo, bHi int) {
+	for aLo < aHi && bLo < bHi && d.a[aLo] == d.b[bLo] {
+		aLo++
+		bLo++
+	}
+	for aLo < aHi && bLo < bHi && d.a[aHi-1] == d.b[bHi-1] {
+		aHi--
+		bHi--
+	}
+
+	switch {
+	case aLo == aHi:
+		for j := bLo; j < bHi; j++ {
+			d.added[j] = true
+		}
+	case bLo == bHi:
+		for i := aLo; i < aHi; i++ {
+			d.deleted[i] = true
+		}
+	default:
+		x, y, u, v := d.middleSnake(aLo, aHi, bLo, bHi)
+		d.compare(aLo, x, bLo, y)
+		d.compare(u, aHi, v, bHi)
+	}
+}
+
+// middleSnake returns the start and the end of the snake in the middle
+// of the shortest edit script between a[aLo:aHi] and b[bLo:bHi], which
+// is found by searching from both ends at once. Positions on diagonal k
+// are those for which x-y == k, and vf and vb hold the furthest x that
+// is reached on each diagonal, going forward and backward respectively.
+func (d *differ) middleSnake(aLo, aHi, bLo, bHi int) (int, int, int, int) {
+	n, m := aHi-aLo, bHi-bLo
+	delta := n - m
+	odd := delta%2 != 0
+
+	max := (n + m + 1) / 2
+	offset := max + 1
+	vf := make([]int, 2*max+3)
+	vb := make([]int, 2*max+3)
+
+	for step := 0; step <= max; step++ {
+		for k := -step; k <= step; k += 2 {
+			var x int
+			if k == -step || (k != step && vf[offset+k-1] < vf[offset+k+1]) {
+				x = vf[offset+k+1]
+			} else {
+				x = vf[offset+k-1] + 1
+			}
+			y := x - k
+			startX, startY := x, y
+			for x < n && y < m && d.a[aLo+x] == d.b[bLo+y] {
+				x++
+				y++
+			}
+			vf[offset+k] = x
+
+			// The backward search is on diagonal delta-k, in
+			// coordinates that start from the ends of the ranges.
+			if back := delta - k; odd && back >= -(step-1) && back <= step-1 && x+vb[offset+back] >= n {
+				return aLo + startX, bLo + startY, aLo + x, bLo + y
+			}
+		}
+
+		for k := -step; k <= step; k += 2 {
+			var x int
+			if k == -step || (k != step && vb[offset+k-1] < vb[offset+k+1]) {
+				x = vb[offset+k+1]
+			} else {
+				x = vb[offset+k-1] + 1
+			}
+			y := x - k
+			startX, startY := x, y
+			for x < n && y < m && d.a[aHi-1-x] == d.b[bHi-1-y] {
+				x++
+				y++
+			}
+			vb[offset+k] = x
+
+			if forward := delta - k; !odd && forward >= -step && forward <= step && x+vf[offset+forward] >= n {
+				return aHi - x, bHi - y, aHi - startX, bHi - startY
+			}
+		}
+	}
+
+	// The searches always overlap once both of them
+	// have gone over half of the edit script.
+	panic("linediff: no middle snake")
+}
@@ -0,0 +1,151 @@
+package linediff
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lcsLength returns the length of the longest common
+// subsequence of a and b, using dynamic programming.
+func lcsLength(a, b []string) int {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	return lcs[0][0]
+}
+
+func TestLines(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		a, b     string
+		expected string
+	}{
+		{
+			desc:     "identical",
+			a:        "a b c",
+			b:        "a b c",
+			expected: "=a =b =c",
+		},
+		{
+			desc:     "empty",
+			expected: "",
+		},
+		{
+			desc:     "insertion",
+			a:        "a c",
+			b:        "a b c",
+			expected: "=a +b =c",
+		},
+		{
+			desc:     "deletion",
+			a:        "a b c",
+			b:        "a c",
+			expected: "=a -b =c",
+		},
+		{
+			desc:     "replacement",
+			a:        "a b c",
+			b:        "a x c",
+			expected: "=a -b +x =c",
+		},
+		{
+			desc:     "everything changed",
+			a:        "a b",
+			b:        "c d",
+			expected: "-a -b +c +d",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			a, b := strings.Fields(test.a), strings.Fields(test.b)
+
+			var rendered []string
+			for _, op := range Lines(a, b) {
+				switch op.Kind {
+				case Equal:
+					rendered = append(rendered, "="+a[op.A])
+				case Delete:
+					rendered = append(rendered, "-"+a[op.A])
+				case Insert:
+					rendered = append(rendered, "+"+b[op.B])
+				}
+			}
+
+			assert.Equal(t, test.expected, strings.Join(rendered, " "))
+		})
+	}
+}
+
+func TestLinesShortest(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	randomLines := func() []string {
+		lines := make([]string, random.Intn(30))
+		for i := range lines {
+			lines[i] = strconv.Itoa(random.Intn(4))
+		}
+		return lines
+	}
+
+	for i := 0; i < 500; i++ {
+		a, b := randomLines(), randomLines()
+		ops := Lines(a, b)
+
+		// The operations should turn a into b, and keep
+		// as many lines as their longest common subsequence.
+		var gotA, gotB []string
+		equal := 0
+		for _, op := range ops {
+			if op.Kind != Insert {
+				gotA = append(gotA, a[op.A])
+			}
+			if op.Kind != Delete {
+				gotB = append(gotB, b[op.B])
+			}
+			if op.Kind == Equal {
+				require.Equal(t, a[op.A], b[op.B])
+				equal++
+			}
+		}
+
+		require.Equal(t, strings.Join(a, " "), strings.Join(gotA, " "))
+		require.Equal(t, strings.Join(b, " "), strings.Join(gotB, " "))
+		require.Equal(t, lcsLength(a, b), equal, "a: %v, b: %v", a, b)
+	}
+}
+
+func TestLinesLarge(t *testing.T) {
+	a := make([]string, 20000)
+	b := make([]string, 20000)
+	for i := range a {
+		a[i] = "line " + strconv.Itoa(i)
+		b[i] = a[i]
+		if i%1000 == 0 {
+			b[i] = "changed " + strconv.Itoa(i)
+		}
+	}
+
+	start := time.Now()
+	ops := Lines(a, b)
+	assert.Len(t, ops, 20020)
+	assert.True(t, time.Since(start) < 5*time.Second)
+}
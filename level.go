@@ -0,0 +1,31 @@
+package disgo
+
+// Level represents the level of an output, which determines
+// on which writer it is written and whether it is shown
+// to the user at all.
+type Level int
+
+const (
+	// LevelDebug is the level of outputs that are only shown
+	// when the terminal's debug mode is enabled.
+	LevelDebug Level = iota
+	// LevelInfo is the level of outputs that are written
+	// on the terminal's default writer.
+	LevelInfo
+	// LevelError is the level of outputs that are written
+	// on the terminal's error writer.
+	LevelError
+)
+
+// String returns the name of the level.
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelError:
+		return "error"
+	}
+	return "unknown"
+}
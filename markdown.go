@@ -0,0 +1,23 @@
+package disgo
+
+import "github.com/Ullaakut/disgo/style"
+
+// Markdown renders Markdown, as style.Markdown does, and writes it on
+// the terminal's default writer. It is wrapped to the width of the
+// terminal, uses ASCII characters if the terminal uses ASCII symbols
+// and the styles of the terminal's theme, and is rendered without
+// escape sequences if colors are disabled on the default writer. Like other outputs, it is queued during steps.
+func (t Terminal) Markdown(source string) {
+	t.Infoln(style.Markdown(source,
+		style.MarkdownWidth(t.availableWidth()),
+		style.MarkdownASCII(t.asciiSymbols()),
+		style.MarkdownPlain(t.stripColors(t.defaultOutput)),
+		style.MarkdownTheme(t.Theme()),
+	))
+}
+
+// Markdown renders Markdown and writes it on the
+// global terminal's default writer.
+func Markdown(source string) {
+	globalTerm.Markdown(source)
+}
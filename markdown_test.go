@@ -0,0 +1,58 @@
+package disgo
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/Ullaakut/disgo/style"
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkdown(t *testing.T) {
+	columns := os.Getenv("COLUMNS")
+	defer os.Setenv("COLUMNS", columns)
+	require.NoError(t, os.Setenv("COLUMNS", "24"))
+
+	out := &bytes.Buffer{}
+	term := NewTerminal(WithDefaultOutput(out), WithColors(false), WithASCII(true))
+
+	term.StartStep("Updating")
+	term.Markdown("## What's new\n\n- Faster installs with **parallel** downloads")
+	term.EndStep()
+
+	assert.Equal(t, "Updating...ok\n"+
+		"  > What's new\n"+
+		"    ----------\n"+
+		"    \n"+
+		"    - Faster installs\n"+
+		"      with parallel\n"+
+		"      downloads\n", out.String())
+}
+
+func TestMarkdownTheme(t *testing.T) {
+	color.NoColor = false
+	defer func() {
+		color.NoColor = true
+	}()
+
+	columns := os.Getenv("COLUMNS")
+	defer os.Setenv("COLUMNS", columns)
+	require.NoError(t, os.Setenv("COLUMNS", "40"))
+
+	out := &bytes.Buffer{}
+	term := NewTerminal(WithDefaultOutput(out), WithColors(true), WithASCII(true), WithTheme(style.MonochromeTheme))
+
+	source := "> [docs](https://example.com)\n\n```json\n{\"a\": 1}\n```"
+	term.Markdown(source)
+
+	expected := style.Markdown(source,
+		style.MarkdownWidth(40),
+		style.MarkdownASCII(true),
+		style.MarkdownTheme(style.MonochromeTheme),
+	)
+	assert.Equal(t, expected+"\n", out.String())
+	assert.NotEqual(t, style.Markdown(source, style.MarkdownWidth(40), style.MarkdownASCII(true)), expected)
+}
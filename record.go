@@ -0,0 +1,279 @@
+package disgo
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Ullaakut/disgo/internal/linediff"
+)
+
+// ErrReplayDiverged is returned by prompts when a session is replayed
+// and a prompt is not the one that was recorded at that point.
+var ErrReplayDiverged = errors.New("replayed session diverged from the recording")
+
+// RecordedEvent is an Event as it is stored in a session recording.
+type RecordedEvent struct {
+	// Kind of event, as returned by EventKind.String.
+	Kind string `json:"kind"`
+	// Offset is the time that elapsed between the first
+	// recorded event and this one.
+	Offset time.Duration `json:"offset"`
+
+	// Level of the output, as returned by Level.String,
+	// for output events.
+	Level string `json:"level,omitempty"`
+	// Text of the event. See Event.
+	Text string `json:"text,omitempty"`
+	// Step that was in progress when the event happened.
+	Step string `json:"step,omitempty"`
+
+	// Answer is the user's raw answer, for prompt events.
+	Answer string `json:"answer,omitempty"`
+	// Err is the message of the error with which
+	// a step failed, for step failure events.
+	Err string `json:"error,omitempty"`
+}
+
+// newRecordedEvent converts an event into a recorded event,
+// whose offset is relative to the given start time.
+func newRecordedEvent(event Event, start time.Time) RecordedEvent {
+	recorded := RecordedEvent{
+		Kind:   event.Kind.String(),
+		Offset: event.Time.Sub(start),
+		Text:   event.Text,
+		Step:   event.Step,
+		Answer: event.Answer,
+	}
+	if event.Kind == EventOutput {
+		recorded.Level = event.Level.String()
+	}
+	if event.Err != nil {
+		recorded.Err = event.Err.Error()
+	}
+
+	return recorded
+}
+
+// String returns a one-line description of the recorded
+// event, which ignores its offset.
+func (e RecordedEvent) String() string {
+	switch e.Kind {
+	case EventOutput.String():
+		return fmt.Sprintf("%s %s %q", e.Kind, e.Level, e.Text)
+	case EventStepFailed.String():
+		return fmt.Sprintf("%s %q: %q", e.Kind, e.Text, e.Err)
+	case EventPrompt.String():
+		return fmt.Sprintf("%s %q: %q", e.Kind, e.Text, e.Answer)
+	}
+	return fmt.Sprintf("%s %q", e.Kind, e.Text)
+}
+
+// Recorder writes the events that happen on terminals to a session
+// recording, with one JSON-encoded RecordedEvent per line. Since
+// events are recorded after their secrets are masked, recordings
+// never contain registered secrets.
+type Recorder struct {
+	mu    sync.Mutex
+	w     io.Writer
+	start time.Time
+	err   error
+}
+
+// NewRecorder creates a recorder that writes a session recording
+// on the given writer.
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{w: w}
+}
+
+// WithRecorder records all of the terminal's outputs, steps and
+// prompts, with their timings, using the given recorder.
+func WithRecorder(r *Recorder) func(*Terminal) {
+	return WithEventHandler(r.record)
+}
+
+func (r *Recorder) record(event Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return
+	}
+
+	if r.start.IsZero() {
+		r.start = event.Time
+	}
+
+	line, err := json.Marshal(newRecordedEvent(event, r.start))
+	if err != nil {
+		r.err = fmt.Errorf("unable to encode event: %v", err)
+		return
+	}
+
+	if _, err := r.w.Write(append(line, '\n')); err != nil {
+		r.err = fmt.Errorf("unable to write event: %v", err)
+	}
+}
+
+// Err returns the first error that occurred while recording,
+// after which the recorder stops recording.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.err
+}
+
+// ReadRecording reads a session recording that was written by a Recorder.
+func ReadRecording(r io.Reader) ([]RecordedEvent, error) {
+	var recording []RecordedEvent
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+
+		var event RecordedEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, fmt.Errorf("unable to parse recording at line %d: %v", line, err)
+		}
+		recording = append(recording, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read recording: %v", err)
+	}
+
+	return recording, nil
+}
+
+// LoadRecording reads a session recording from a file.
+func LoadRecording(path string) ([]RecordedEvent, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open recording: %v", err)
+	}
+	defer file.Close()
+
+	return ReadRecording(file)
+}
+
+// Replay feeds the answers of a session recording back into prompts,
+// in the order in which they were recorded, and records what the
+// terminal produces so that it can be compared with the recording.
+type Replay struct {
+	mu        sync.Mutex
+	recording []RecordedEvent
+	prompts   []RecordedEvent
+	produced  []RecordedEvent
+	start     time.Time
+}
+
+// NewReplay creates a replay of the given session recording.
+func NewReplay(recording []RecordedEvent) *Replay {
+	r := &Replay{recording: recording}
+	for _, event := range recording {
+		if event.Kind == EventPrompt.String() {
+			r.prompts = append(r.prompts, event)
+		}
+	}
+
+	return r
+}
+
+// WithReplay answers the terminal's prompts with the answers of the
+// given replay, before any scripted answer. When a prompt's label does
+// not match the one that was recorded at that point, the prompt returns
+// ErrReplayDiverged. Once all recorded answers were used, prompts behave
+// as if there was no replay.
+func WithReplay(r *Replay) func(*Terminal) {
+	return func(term *Terminal) {
+		term.replay = r
+		WithEventHandler(r.record)(term)
+	}
+}
+
+func (r *Replay) record(event Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.start.IsZero() {
+		r.start = event.Time
+	}
+	r.produced = append(r.produced, newRecordedEvent(event, r.start))
+}
+
+// answer returns the next recorded answer, if there is one left.
+// It is safe to call on a nil replay.
+func (r *Replay) answer(label string) (string, bool, error) {
+	if r == nil {
+		return "", false, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.prompts) == 0 {
+		return "", false, nil
+	}
+
+	next := r.prompts[0]
+	if next.Text != label {
+		return "", false, fmt.Errorf("%w: expected prompt %q, got %q", ErrReplayDiverged, next.Text, label)
+	}
+	r.prompts = r.prompts[1:]
+
+	return next.Answer, true, nil
+}
+
+// Diff compares the events that were produced during the replay with
+// the recorded ones, regardless of their timings. It returns an empty
+// string if they are identical, and otherwise lists all events, with
+// recorded events that were not produced prefixed with `-` and produced
+// events that were not recorded prefixed with `+`.
+func (r *Replay) Diff() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	recorded := make([]string, len(r.recording))
+	for i, event := range r.recording {
+		recorded[i] = event.String()
+	}
+	produced := make([]string, len(r.produced))
+	for i, event := range r.produced {
+		produced[i] = event.String()
+	}
+
+	return diffLines(recorded, produced)
+}
+
+// diffLines returns a line diff of a and b, or an
+// empty string if they are identical.
+func diffLines(a, b []string) string {
+	var diff strings.Builder
+	changed := false
+	for _, op := range linediff.Lines(a, b) {
+		switch op.Kind {
+		case linediff.Equal:
+			diff.WriteString("  " + a[op.A] + "\n")
+		case linediff.Delete:
+			diff.WriteString("- " + a[op.A] + "\n")
+			changed = true
+		case linediff.Insert:
+			diff.WriteString("+ " + b[op.B] + "\n")
+			changed = true
+		}
+	}
+
+	if !changed {
+		return ""
+	}
+	return diff.String()
+}
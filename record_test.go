@@ -0,0 +1,164 @@
+package disgo
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// session is a program that is recorded and replayed.
+func session(term *Terminal, extraOutput bool) error {
+	term.StartStep("Preparing")
+	term.Infoln("prepared")
+	term.EndStep()
+
+	ok, err := term.Confirm(Confirmation{Label: "Install?"})
+	if err != nil {
+		return err
+	}
+	if ok {
+		term.Infoln("Installing")
+	}
+	if extraOutput {
+		term.Errorln("unexpected")
+	}
+
+	term.StartStep("Cleaning up")
+	return term.FailStep(errors.New("disk full"))
+}
+
+func TestRecorder(t *testing.T) {
+	recording := &bytes.Buffer{}
+	recorder := NewRecorder(recording)
+
+	term := NewTerminal(
+		WithDefaultOutput(&bytes.Buffer{}),
+		WithErrorOutput(&bytes.Buffer{}),
+		WithReader(strings.NewReader("yes\n")),
+		WithRecorder(recorder),
+	)
+	term.clock = fakeClock(time.Date(2019, 1, 1, 12, 0, 0, 0, time.UTC))
+	term.RegisterSecret("hunter2")
+
+	_ = session(term, false)
+	term.Infoln("using hunter2")
+	require.NoError(t, recorder.Err())
+
+	events, err := ReadRecording(recording)
+	require.NoError(t, err)
+	require.Len(t, events, 8)
+
+	assert.Equal(t, RecordedEvent{Kind: "step_started", Text: "Preparing", Step: "Preparing"}, events[0])
+	assert.Equal(t, RecordedEvent{Kind: "output", Offset: time.Second, Level: "info", Text: "prepared\n", Step: "Preparing"}, events[1])
+	assert.Equal(t, RecordedEvent{Kind: "prompt", Offset: 3 * time.Second, Text: "Install?", Answer: "yes"}, events[3])
+	assert.Equal(t, RecordedEvent{Kind: "step_failed", Offset: 6 * time.Second, Text: "Cleaning up", Step: "Cleaning up", Err: "disk full"}, events[6])
+	assert.Equal(t, "using ****\n", events[7].Text)
+}
+
+func TestReplay(t *testing.T) {
+	recording := &bytes.Buffer{}
+	term := NewTerminal(
+		WithDefaultOutput(&bytes.Buffer{}),
+		WithErrorOutput(&bytes.Buffer{}),
+		WithReader(strings.NewReader("y\n")),
+		WithRecorder(NewRecorder(recording)),
+	)
+	_ = session(term, false)
+
+	events, err := ReadRecording(bytes.NewReader(recording.Bytes()))
+	require.NoError(t, err)
+
+	t.Run("identical session", func(t *testing.T) {
+		defaultOut := &bytes.Buffer{}
+		replay := NewReplay(events)
+		term := NewTerminal(
+			WithDefaultOutput(defaultOut),
+			WithErrorOutput(&bytes.Buffer{}),
+			WithInteractive(false),
+			WithReplay(replay),
+		)
+
+		_ = session(term, false)
+
+		assert.Empty(t, replay.Diff())
+		assert.Contains(t, defaultOut.String(), "Install? [y/n] y\nInstalling\n")
+	})
+
+	t.Run("different outputs", func(t *testing.T) {
+		replay := NewReplay(events)
+		term := NewTerminal(
+			WithDefaultOutput(&bytes.Buffer{}),
+			WithErrorOutput(&bytes.Buffer{}),
+			WithInteractive(false),
+			WithReplay(replay),
+		)
+
+		_ = session(term, true)
+
+		assert.Equal(t, "  step_started \"Preparing\"\n"+
+			"  output info \"prepared\\n\"\n"+
+			"  step_ended \"Preparing\"\n"+
+			"  prompt \"Install?\": \"y\"\n"+
+			"  output info \"Installing\\n\"\n"+
+			"+ output error \"unexpected\\n\"\n"+
+			"  step_started \"Cleaning up\"\n"+
+			"  step_failed \"Cleaning up\": \"disk full\"\n", replay.Diff())
+	})
+
+	t.Run("different prompt", func(t *testing.T) {
+		term := NewTerminal(WithInteractive(false), WithReplay(NewReplay(events)))
+
+		_, err := term.Confirm(Confirmation{Label: "Uninstall?"})
+		assert.True(t, errors.Is(err, ErrReplayDiverged))
+	})
+
+	t.Run("missing prompt", func(t *testing.T) {
+		term := NewTerminal(WithDefaultOutput(&bytes.Buffer{}), WithInteractive(false), WithReplay(NewReplay(nil)))
+
+		_, err := term.Confirm(Confirmation{Label: "Install?"})
+		assert.True(t, errors.Is(err, ErrMissingAnswer))
+	})
+}
+
+func TestReadRecordingError(t *testing.T) {
+	_, err := ReadRecording(strings.NewReader("{\"kind\":\"output\"}\n\nnot json\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 3")
+}
+
+func TestDiffLines(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		a        []string
+		b        []string
+		expected string
+	}{
+		{
+			desc: "identical",
+			a:    []string{"one", "two"},
+			b:    []string{"one", "two"},
+		},
+		{
+			desc:     "changed line",
+			a:        []string{"one", "two", "three"},
+			b:        []string{"one", "deux", "three"},
+			expected: "  one\n- two\n+ deux\n  three\n",
+		},
+		{
+			desc:     "empty",
+			a:        []string{"one"},
+			expected: "- one\n",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, diffLines(test.a, test.b))
+		})
+	}
+}
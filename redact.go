@@ -0,0 +1,148 @@
+package disgo
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// RedactionMask is the string with which secrets are replaced in outputs.
+const RedactionMask = "****"
+
+var (
+	// BearerTokenPattern matches the token of bearer authorization
+	// headers, such as `Authorization: Bearer <token>`.
+	BearerTokenPattern = regexp.MustCompile(`(?i)bearer\s+([A-Za-z0-9\-._~+/]+=*)`)
+
+	// AWSAccessKeyPattern matches AWS access key IDs.
+	AWSAccessKeyPattern = regexp.MustCompile(`\b((?:AKIA|ASIA)[0-9A-Z]{16})\b`)
+
+	// AWSSecretKeyPattern matches AWS secret access keys when they are
+	// assigned to a variable or a field that names them as such.
+	AWSSecretKeyPattern = regexp.MustCompile(`(?i)aws_?secret_?(?:access_?)?key["']?\s*[:=]\s*["']?([A-Za-z0-9/+=]{40})`)
+)
+
+// Redactor replaces secrets with a mask in a terminal's outputs.
+// Secrets can either be literal values, or patterns. When a pattern
+// contains capturing groups, only the captured text is masked,
+// otherwise the whole match is.
+//
+// A Redactor is safe for concurrent use.
+type Redactor struct {
+	mu       sync.RWMutex
+	secrets  []string
+	patterns []*regexp.Regexp
+}
+
+// NewRedactor creates a new Redactor that masks text
+// matching the given patterns.
+func NewRedactor(patterns ...*regexp.Regexp) *Redactor {
+	return &Redactor{
+		patterns: patterns,
+	}
+}
+
+// AddSecret registers a literal secret value to be masked.
+// Empty values are ignored.
+func (r *Redactor) AddSecret(value string) {
+	if value == "" {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.secrets = append(r.secrets, value)
+
+	// Longer secrets are masked first, so that a secret that
+	// contains another one is masked entirely.
+	sort.SliceStable(r.secrets, func(i, j int) bool {
+		return len(r.secrets[i]) > len(r.secrets[j])
+	})
+}
+
+// AddPattern registers a pattern of secrets to be masked.
+func (r *Redactor) AddPattern(pattern *regexp.Regexp) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.patterns = append(r.patterns, pattern)
+}
+
+// Redact returns the given content with all of the secrets it
+// contains replaced with RedactionMask. It is safe to call on a
+// nil Redactor, in which case content is returned as is.
+func (r *Redactor) Redact(content string) string {
+	if r == nil {
+		return content
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, secret := range r.secrets {
+		content = strings.Replace(content, secret, RedactionMask, -1)
+	}
+
+	for _, pattern := range r.patterns {
+		content = redactPattern(pattern, content)
+	}
+
+	return content
+}
+
+// redactPattern masks the parts of content that match the given
+// pattern, or only its capturing groups if it has any.
+func redactPattern(pattern *regexp.Regexp, content string) string {
+	if pattern.NumSubexp() == 0 {
+		return pattern.ReplaceAllString(content, RedactionMask)
+	}
+
+	var (
+		result strings.Builder
+		last   int
+	)
+	for _, match := range pattern.FindAllStringSubmatchIndex(content, -1) {
+		// Skip the indexes of the whole match, and mask
+		// each capturing group that matched something.
+		for group := 2; group < len(match); group += 2 {
+			start, end := match[group], match[group+1]
+			if start < last || start == end {
+				continue
+			}
+
+			result.WriteString(content[last:start])
+			result.WriteString(RedactionMask)
+			last = end
+		}
+	}
+	result.WriteString(content[last:])
+
+	return result.String()
+}
+
+// WithRedactor sets the redactor that is used to mask secrets in all
+// of the terminal's outputs. Child terminals share their parent's
+// redactor.
+func WithRedactor(redactor *Redactor) func(*Terminal) {
+	return func(term *Terminal) {
+		term.redactor = redactor
+	}
+}
+
+// RegisterSecret registers a literal secret value that is then
+// masked in all of the terminal's outputs, including outputs that
+// were queued during the current step.
+func (t *Terminal) RegisterSecret(value string) {
+	if t.redactor == nil {
+		t.redactor = NewRedactor()
+	}
+	t.redactor.AddSecret(value)
+}
+
+// RegisterSecret registers a literal secret value that is then
+// masked in all of the global terminal's outputs.
+func RegisterSecret(value string) {
+	globalTerm.RegisterSecret(value)
+}
@@ -0,0 +1,53 @@
+package disgo
+
+import "github.com/fatih/color"
+
+// With creates a child terminal that shares the writers, the reader
+// and the current step of its parent, but on which the given options
+// are applied. This makes it possible for different parts of a program
+// to use their own prefix, debug mode or colors without affecting
+// the rest of the program.
+//
+// Steps started or ended on a child terminal are shared with its parent
+// and all of its parent's other children.
+func (t *Terminal) With(options ...func(*Terminal)) *Terminal {
+	child := *t
+	child.parent = t.root()
+	child.step = nil
+
+	// Options given to a child terminal should not change the
+	// package-wide color settings, which its parent relies on.
+	noColor := color.NoColor
+	defer func() {
+		color.NoColor = noColor
+	}()
+
+	for _, option := range options {
+		option(&child)
+	}
+
+	return &child
+}
+
+// With creates a child terminal from the global terminal, on
+// which the given options are applied.
+func With(options ...func(*Terminal)) *Terminal {
+	return globalTerm.With(options...)
+}
+
+// Scope creates a child terminal whose outputs are prefixed with the
+// given name, in addition to the prefix of its parent.
+func (t *Terminal) Scope(name string) *Terminal {
+	prefix := "[" + name + "]"
+	if t.prefix != "" {
+		prefix = t.prefix + " " + prefix
+	}
+
+	return t.With(WithPrefix(prefix))
+}
+
+// Scope creates a child terminal from the global terminal, whose
+// outputs are prefixed with the given name.
+func Scope(name string) *Terminal {
+	return globalTerm.Scope(name)
+}
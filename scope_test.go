@@ -0,0 +1,96 @@
+package disgo
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithDoesNotAffectParent(t *testing.T) {
+	defaultOut := &bytes.Buffer{}
+
+	parent := &Terminal{
+		defaultOutput: defaultOut,
+	}
+	child := parent.With(WithDebug(true), WithPrefix("child"))
+
+	parent.Debugln("hidden")
+	child.Debugln("shown")
+	parent.Infoln("from parent")
+
+	assert.False(t, parent.debug)
+	assert.Empty(t, parent.prefix)
+	assert.Equal(t, "child shown\nfrom parent\n", defaultOut.String())
+}
+
+func TestWithSharesStep(t *testing.T) {
+	defaultOut := &bytes.Buffer{}
+
+	parent := &Terminal{
+		defaultOutput: defaultOut,
+	}
+	child := parent.With(WithDebug(true))
+
+	// A step started by the parent should queue the child's outputs,
+	// including debug outputs that the parent would not show.
+	parent.StartStep("Simulated task #1")
+	child.Infoln("25%")
+	child.Debugln("debug from child")
+	parent.Debugln("debug from parent")
+	child.EndStep()
+
+	assert.Nil(t, parent.step)
+	assert.Equal(t, "Simulated task #1...ok\n  > 25%\n  > debug from child\n", defaultOut.String())
+
+	// A step started by the child should also be the parent's.
+	defaultOut.Reset()
+	child.StartStep("Simulated task #2")
+	parent.Infoln("from parent")
+	assert.NotNil(t, parent.step)
+	parent.EndStep()
+
+	assert.Equal(t, "Simulated task #2...ok\n  > from parent\n", defaultOut.String())
+}
+
+func TestScope(t *testing.T) {
+	defaultOut := &bytes.Buffer{}
+
+	term := &Terminal{
+		defaultOutput: defaultOut,
+	}
+
+	plugin := term.Scope("plugin")
+	plugin.Infoln("loaded")
+	plugin.Scope("db").Infoln("connected")
+
+	assert.Equal(t, "[plugin] loaded\n[plugin] [db] connected\n", defaultOut.String())
+}
+
+func TestWithColors(t *testing.T) {
+	defaultOut := &bytes.Buffer{}
+
+	color.NoColor = false
+	defer func() {
+		color.NoColor = true
+	}()
+
+	parent := &Terminal{
+		defaultOutput: defaultOut,
+	}
+	child := parent.With(WithColors(false))
+
+	// Disabling colors on the child should not disable them
+	// process-wide.
+	assert.False(t, color.NoColor)
+
+	green := color.New(color.FgGreen).SprintFunc()
+	child.Infoln(green("child"))
+	assert.Equal(t, "child\n", defaultOut.String())
+
+	defaultOut.Reset()
+	parent.Infoln(green("parent"))
+	assert.Equal(t, green("parent")+"\n", defaultOut.String())
+	assert.NotEqual(t, "parent\n", defaultOut.String())
+}
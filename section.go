@@ -0,0 +1,47 @@
+package disgo
+
+import (
+	"strings"
+
+	"github.com/Ullaakut/disgo/style"
+)
+
+// Section writes a heading on the terminal's default writer, styled
+// as important, and underlined by a rule that spans the width of the
+// terminal, minus the decoration and indentation of outputs. The rule
+// is drawn with ASCII characters if the terminal uses ASCII symbols.
+// Like other outputs, sections are queued during steps.
+func (t Terminal) Section(title string) {
+	theme := t.Theme()
+	title = t.redactor.Redact(title)
+
+	rule := "─"
+	if t.asciiSymbols() {
+		rule = "-"
+	}
+
+	t.Infoln(t.sprint(t.defaultOutput, theme.Important, title))
+	t.Infoln(t.sprint(t.defaultOutput, theme.Trace, strings.Repeat(rule, t.availableWidth())))
+}
+
+// Section writes a heading on the global terminal's default writer.
+func Section(title string) {
+	globalTerm.Section(title)
+}
+
+// Box writes a body in a bordered panel on the terminal's default
+// writer, as rendered by style.Box. The box fits in the width of the
+// terminal, and its borders are drawn with ASCII characters if the
+// terminal uses ASCII symbols. Like other outputs, boxes are queued
+// during steps. Secrets are masked before the body is wrapped, so that
+// they are masked even if they are longer than the box is wide.
+func (t Terminal) Box(title, body string) {
+	title, body = t.redactor.Redact(title), t.redactor.Redact(body)
+	t.Infoln(style.Box(title, body, style.BoxWidth(t.availableWidth()), style.BoxASCII(t.asciiSymbols())))
+}
+
+// Box writes a body in a bordered panel on the global
+// terminal's default writer.
+func Box(title, body string) {
+	globalTerm.Box(title, body)
+}
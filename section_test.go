@@ -0,0 +1,99 @@
+package disgo
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSection(t *testing.T) {
+	columns := os.Getenv("COLUMNS")
+	defer os.Setenv("COLUMNS", columns)
+	require.NoError(t, os.Setenv("COLUMNS", "10"))
+
+	testCases := []struct {
+		desc     string
+		ascii    asciiMode
+		expected string
+	}{
+		{
+			desc:     "unicode rule",
+			ascii:    asciiDisabled,
+			expected: "Results\n──────────\n",
+		},
+		{
+			desc:     "ascii rule",
+			ascii:    asciiEnabled,
+			expected: "Results\n----------\n",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			term := &Terminal{defaultOutput: out, ascii: test.ascii}
+
+			term.Section("Results")
+
+			assert.Equal(t, test.expected, out.String())
+		})
+	}
+}
+
+func TestSectionQueuedDuringSteps(t *testing.T) {
+	columns := os.Getenv("COLUMNS")
+	defer os.Setenv("COLUMNS", columns)
+	require.NoError(t, os.Setenv("COLUMNS", "8"))
+
+	out := &bytes.Buffer{}
+	term := NewTerminal(WithDefaultOutput(out), WithASCII(true), WithColors(false))
+
+	term.StartStep("Installing")
+	term.Section("Done")
+	term.EndStep()
+
+	assert.Equal(t, "Installing...ok\n  > Done\n  > ----\n", out.String())
+}
+
+func TestBox(t *testing.T) {
+	columns := os.Getenv("COLUMNS")
+	defer os.Setenv("COLUMNS", columns)
+	require.NoError(t, os.Setenv("COLUMNS", "14"))
+
+	out := &bytes.Buffer{}
+	term := &Terminal{defaultOutput: out, ascii: asciiEnabled}
+
+	term.Box("Token", "abc def ghi jkl")
+
+	assert.Equal(t, "+- Token -+\n"+
+		"| abc def |\n"+
+		"| ghi jkl |\n"+
+		"+---------+\n", out.String())
+}
+
+func TestBoxMasksSecrets(t *testing.T) {
+	columns := os.Getenv("COLUMNS")
+	defer os.Setenv("COLUMNS", columns)
+	require.NoError(t, os.Setenv("COLUMNS", "20"))
+
+	out := &bytes.Buffer{}
+	term := NewTerminal(WithDefaultOutput(out), WithColors(false), WithASCII(true))
+
+	// The secret is longer than the box is wide, so it would be
+	// split across lines if it was masked after being wrapped.
+	secret := "s3cr3t-0123456789abcdefghij"
+	term.RegisterSecret(secret)
+	term.Section("Token " + secret)
+	term.Box("Key "+secret, "token: "+secret)
+
+	assert.NotContains(t, out.String(), "s3cr3t")
+	assert.NotContains(t, out.String(), "0123456789")
+	assert.Equal(t, "Token ****\n"+
+		"--------------------\n"+
+		"+- Key **** --+\n"+
+		"| token: **** |\n"+
+		"+-------------+\n", out.String())
+}
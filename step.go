@@ -3,45 +3,30 @@ package disgo
 import (
 	"fmt"
 	"strings"
-
-	"github.com/Ullaakut/disgo/style"
 )
 
-type outputLevel int
-
-const (
-	levelDebug outputLevel = iota
-	levelInfo
-	levelError
-)
+// queueIndent is prepended to the outputs that were queued during a
+// step, to make it obvious that they are part of its processing.
+const queueIndent = "  > "
 
 type stepOutput struct {
 	content string
-	level   outputLevel
+	level   Level
+	// Timestamp and prefix of the output, computed
+	// when the output was produced.
+	decoration string
 }
 
 type step struct {
+	label string
 	queue []stepOutput
 }
 
-func (s *step) pushDebug(content string) {
-	s.queue = append(s.queue, stepOutput{
-		level:   levelDebug,
-		content: content,
-	})
-}
-
-func (s *step) pushInfo(content string) {
+func (s *step) push(level Level, content, decoration string) {
 	s.queue = append(s.queue, stepOutput{
-		level:   levelInfo,
-		content: content,
-	})
-}
-
-func (s *step) pushError(content string) {
-	s.queue = append(s.queue, stepOutput{
-		level:   levelError,
-		content: content,
+		level:      level,
+		content:    content,
+		decoration: decoration,
 	})
 }
 
@@ -50,13 +35,22 @@ func (s *step) pushError(content string) {
 // until the step is ended or failed. If a step was already in
 // progress, it is considered to have been ended successfully.
 func (t *Terminal) StartStep(label string) {
-	if t.step != nil {
+	root := t.root()
+	if root.step != nil {
 		t.EndStep()
 	}
 
-	fmt.Fprint(t.defaultOutput, label, "...")
+	t.print(t.defaultOutput, label+"...")
+	t.tee(teeTagStep, "", label+"...")
 
-	t.step = &step{}
+	root.step = &step{
+		label: label,
+	}
+
+	t.emit(Event{
+		Kind: EventStepStarted,
+		Text: label,
+	})
 }
 
 // StartStep sets a step in the global terminal, which prints
@@ -90,15 +84,27 @@ func StartStepf(format string, a ...interface{}) {
 // was in progress, and returns the given error for error
 // handling.
 func (t *Terminal) FailStep(err error) error {
-	if t.step == nil {
+	root := t.root()
+	if root.step == nil {
 		return err
 	}
 
-	fmt.Fprintln(t.defaultOutput, style.Failure("ko"))
+	theme := t.Theme()
+	t.print(t.defaultOutput, t.sprint(t.defaultOutput, theme.Failure, theme.StatusKO)+"\n")
+	if err != nil {
+		t.tee(teeTagStep, "", fmt.Sprintf("%s...ko: %v", root.step.label, err))
+	} else {
+		t.tee(teeTagStep, "", root.step.label+"...ko")
+	}
+	t.emit(Event{
+		Kind: EventStepFailed,
+		Text: root.step.label,
+		Err:  err,
+	})
 
 	t.printQueue()
 
-	t.step = nil
+	root.step = nil
 	return err
 }
 
@@ -132,15 +138,22 @@ func FailStepf(format string, a ...interface{}) error {
 // prints all of the outputs that were queued while the step
 // was in progress.
 func (t *Terminal) EndStep() {
-	if t.step == nil {
+	root := t.root()
+	if root.step == nil {
 		return
 	}
 
-	fmt.Fprintln(t.defaultOutput, style.Success("ok"))
+	theme := t.Theme()
+	t.print(t.defaultOutput, t.sprint(t.defaultOutput, theme.Success, theme.StatusOK)+"\n")
+	t.tee(teeTagStep, "", root.step.label+"...ok")
+	t.emit(Event{
+		Kind: EventStepEnded,
+		Text: root.step.label,
+	})
 
 	t.printQueue()
 
-	t.step = nil
+	root.step = nil
 }
 
 // EndStep ends a step with a success state on the global.
@@ -151,26 +164,48 @@ func EndStep() {
 	globalTerm.EndStep()
 }
 
+// currentStep returns the step that is in progress, which is owned
+// by the terminal's parent if it has one.
+func (t Terminal) currentStep() *step {
+	if t.parent != nil {
+		return t.parent.step
+	}
+	return t.step
+}
+
+// root returns the terminal that owns the current step.
+func (t *Terminal) root() *Terminal {
+	if t.parent != nil {
+		return t.parent
+	}
+	return t
+}
+
 // printQueue prints all of the outputs that were queued during a step,
-// neatly indented after that step is ended.
+// neatly indented after that step is ended. Debug outputs were already
+// filtered out when they were queued, based on the settings of the
+// terminal that produced them.
 func (t Terminal) printQueue() {
-	for _, output := range t.step.queue {
-		// Trim the last newline from the output's content.
-		output.content = strings.TrimSuffix(output.content, "\n")
-		// Indent the content to make it obvious that it is
-		// part of a step's processing.
-		output.content = strings.Replace(output.content, "\n", "\n    ", -1)
-
-		// Print the output on the proper writer.
-		switch output.level {
-		case levelDebug:
-			if t.debug {
-				fmt.Fprintf(t.defaultOutput, "  > %s\n", style.Trace(output.content))
+	theme := t.Theme()
+	for _, output := range t.currentStep().queue {
+		outputStyle, w := theme.Trace, t.defaultOutput
+		if output.level == LevelError {
+			outputStyle, w = theme.Failure, t.errorOutput
+		}
+
+		// Trim the last newline from the output's content, and style
+		// each of its lines separately so that their indentation is not
+		// styled.
+		lines := strings.Split(strings.TrimSuffix(output.content, "\n"), "\n")
+		for i, line := range lines {
+			if line != "" {
+				lines[i] = t.sprint(w, outputStyle, line)
 			}
-		case levelInfo:
-			fmt.Fprintf(t.defaultOutput, "  > %s\n", style.Trace(output.content))
-		case levelError:
-			fmt.Fprintf(t.errorOutput, "  > %s\n", style.Failure(output.content))
 		}
+
+		// Indent the continuation lines like the first one.
+		content := strings.Join(lines, "\n"+output.decoration+strings.Repeat(" ", len(queueIndent)))
+
+		t.write(w, fmt.Sprintf("%s%s%s\n", output.decoration, queueIndent, content))
 	}
 }
@@ -0,0 +1,145 @@
+package style
+
+import (
+	"strings"
+)
+
+// DefaultBoxWidth is the maximum number of columns
+// that boxes take, unless specified otherwise.
+const DefaultBoxWidth = 80
+
+// BoxOptions configures how a box is rendered.
+type BoxOptions struct {
+	// Width is the maximum number of columns that the box takes,
+	// including its borders, which is usually the width of the
+	// terminal. Boxes are as narrow as their content allows, and
+	// lines that are too long are wrapped.
+	Width int
+	// Padding is the number of spaces between the
+	// borders of the box and its content.
+	Padding int
+	// ASCII makes the box use ASCII characters for its
+	// borders, instead of UTF-8 ones.
+	ASCII bool
+	// Border is the style applied to the borders of the box.
+	Border Style
+}
+
+// BoxWidth sets the maximum number of columns that a box takes.
+func BoxWidth(width int) func(*BoxOptions) {
+	return func(options *BoxOptions) {
+		options.Width = width
+	}
+}
+
+// BoxPadding sets the number of spaces between the
+// borders of a box and its content.
+func BoxPadding(padding int) func(*BoxOptions) {
+	return func(options *BoxOptions) {
+		options.Padding = padding
+	}
+}
+
+// BoxASCII makes a box use ASCII characters for its borders,
+// instead of UTF-8 ones.
+func BoxASCII(enabled bool) func(*BoxOptions) {
+	return func(options *BoxOptions) {
+		options.ASCII = enabled
+	}
+}
+
+// BoxBorder sets the style applied to the borders of a box,
+// such as Style{"yellow"} for warnings.
+func BoxBorder(border Style) func(*BoxOptions) {
+	return func(options *BoxOptions) {
+		options.Border = border
+	}
+}
+
+// minBoxContentWidth is the width under
+// which box contents are not wrapped.
+const minBoxContentWidth = 10
+
+// Box renders a body in a bordered panel, for notices that must not be
+// missed, such as breaking changes or credentials that were just
+// created. The title, if it is not empty, is rendered in bold in the top
+// border. The body is wrapped so that the box fits in its width. By
+// default, boxes are at most DefaultBoxWidth columns wide, have a
+// padding of one space, and use ASCII borders if the terminal does not
+// support UTF-8 according to SupportsUnicode. The result has no
+// trailing newline.
+func Box(title, body string, options ...func(*BoxOptions)) string {
+	opts := BoxOptions{
+		Width:   DefaultBoxWidth,
+		Padding: 1,
+		ASCII:   !SupportsUnicode(),
+	}
+	for _, option := range options {
+		option(&opts)
+	}
+	if opts.Padding < 0 {
+		opts.Padding = 0
+	}
+
+	borders := unicodeBorders
+	ellipsis := Ellipsis
+	if opts.ASCII {
+		borders = asciiBorders
+		ellipsis = "..."
+	}
+
+	// The content is surrounded by a border and
+	// the padding on each side of it.
+	frame := 2 + 2*opts.Padding
+	available := opts.Width - frame
+	if available < minBoxContentWidth {
+		available = minBoxContentWidth
+	}
+
+	lines := strings.Split(Wrap(strings.TrimRight(body, "\n"), available), "\n")
+
+	// The top border holds the title between spaces, after one
+	// horizontal character and before at least one.
+	if title != "" {
+		titleWidth := available + 2*opts.Padding - 4
+		if titleWidth < 1 {
+			titleWidth = 1
+		}
+		title = Truncate(strings.Replace(title, "\n", " ", -1), titleWidth, ellipsis)
+	}
+
+	width := 0
+	if title != "" {
+		width = Width(title) + 4 - 2*opts.Padding
+	}
+	for _, line := range lines {
+		if w := Width(line); w > width {
+			width = w
+		}
+	}
+
+	border := func(s string) string {
+		if len(opts.Border) == 0 {
+			return s
+		}
+		return opts.Border.Sprint(s)
+	}
+	inner := width + 2*opts.Padding
+	padding := strings.Repeat(" ", opts.Padding)
+
+	top := borders.topLeft + strings.Repeat(borders.horizontal, inner) + borders.topRight
+	if title != "" {
+		top = border(borders.topLeft+borders.horizontal) + " " + Important(title) + " " +
+			border(strings.Repeat(borders.horizontal, inner-Width(title)-3)+borders.topRight)
+	} else {
+		top = border(top)
+	}
+
+	rendered := []string{top}
+	for _, line := range lines {
+		rendered = append(rendered, border(borders.vertical)+padding+pad(line, width)+padding+border(borders.vertical))
+	}
+	rendered = append(rendered, border(borders.bottomLeft+strings.Repeat(borders.horizontal, inner)+borders.bottomRight))
+
+	return strings.Join(rendered, "\n")
+}
@@ -0,0 +1,85 @@
+package style
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBox(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		title    string
+		body     string
+		options  []func(*BoxOptions)
+		expected string
+	}{
+		{
+			desc:  "fits its content",
+			title: "",
+			body:  "hello\nworld!",
+			options: []func(*BoxOptions){
+				BoxASCII(false),
+			},
+			expected: "┌────────┐\n" +
+				"│ hello  │\n" +
+				"│ world! │\n" +
+				"└────────┘",
+		},
+		{
+			desc:  "title",
+			title: "Notice",
+			body:  "hi",
+			options: []func(*BoxOptions){
+				BoxASCII(false),
+			},
+			expected: "┌─ Notice ─┐\n" +
+				"│ hi       │\n" +
+				"└──────────┘",
+		},
+		{
+			desc:  "ascii and padding",
+			title: "Note",
+			body:  "hello",
+			options: []func(*BoxOptions){
+				BoxASCII(true),
+				BoxPadding(2),
+			},
+			expected: "+- Note --+\n" +
+				"|  hello  |\n" +
+				"+---------+",
+		},
+		{
+			desc:  "body is wrapped",
+			title: "",
+			body:  "the quick brown fox jumps over the lazy dog",
+			options: []func(*BoxOptions){
+				BoxASCII(true),
+				BoxWidth(20),
+			},
+			expected: "+-----------------+\n" +
+				"| the quick brown |\n" +
+				"| fox jumps over  |\n" +
+				"| the lazy dog    |\n" +
+				"+-----------------+",
+		},
+		{
+			desc:  "title is truncated",
+			title: "a very long title that does not fit",
+			body:  "hi",
+			options: []func(*BoxOptions){
+				BoxASCII(true),
+				BoxWidth(16),
+			},
+			expected: "+- a very ... -+\n" +
+				"| hi           |\n" +
+				"+--------------+",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, Box(test.title, test.body, test.options...))
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package style
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ColorProfile represents the colors that a terminal supports.
+type ColorProfile int
+
+const (
+	// ANSI terminals support the 16 basic colors.
+	ANSI ColorProfile = iota
+	// ANSI256 terminals support a palette of 256 colors.
+	ANSI256
+	// TrueColor terminals support 24-bit RGB colors.
+	TrueColor
+)
+
+// Profile is the color profile that styles are rendered with. RGB and
+// 256 colors are downsampled to the nearest color that it supports. It
+// is detected from the environment by default, and can be changed.
+var Profile = DetectColorProfile()
+
+// DetectColorProfile detects the color profile of the terminal from
+// the COLORTERM and TERM environment variables.
+func DetectColorProfile() ColorProfile {
+	switch strings.ToLower(os.Getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return TrueColor
+	}
+
+	term := strings.ToLower(os.Getenv("TERM"))
+	switch {
+	case strings.Contains(term, "truecolor"), strings.Contains(term, "24bit"), strings.Contains(term, "direct"):
+		return TrueColor
+	case strings.Contains(term, "256color"):
+		return ANSI256
+	}
+
+	return ANSI
+}
+
+// RGB returns a style that colors messages with the given color,
+// in the `#rrggbb` or `#rgb` hexadecimal notation.
+func RGB(hex string) Style {
+	return Style{hex}
+}
+
+// BgRGB returns a style that colors the background of messages with
+// the given color, in the `#rrggbb` or `#rgb` hexadecimal notation.
+func BgRGB(hex string) Style {
+	return Style{"bg-" + hex}
+}
+
+// Color256 returns a style that colors messages with the
+// color that has the given index in the palette of 256 colors.
+func Color256(index int) Style {
+	return Style{"color-" + strconv.Itoa(index)}
+}
+
+// BgColor256 returns a style that colors the background of messages
+// with the color that has the given index in the palette of 256 colors.
+func BgColor256(index int) Style {
+	return Style{"bg-color-" + strconv.Itoa(index)}
+}
+
+// rgb is a 24-bit color.
+type rgb struct {
+	r, g, b int
+}
+
+// basicColors are the 16 basic colors, as xterm renders them by default.
+var basicColors = [16]rgb{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// cubeLevels are the levels of each component of
+// the 6x6x6 color cube of the palette of 256 colors.
+var cubeLevels = [6]int{0, 95, 135, 175, 215, 255}
+
+// parseHex parses a color in the `#rrggbb` or `#rgb` notation.
+func parseHex(hex string) (rgb, error) {
+	digits := strings.TrimPrefix(hex, "#")
+	if len(digits) == 3 {
+		digits = string([]byte{digits[0], digits[0], digits[1], digits[1], digits[2], digits[2]})
+	}
+	if !strings.HasPrefix(hex, "#") || len(digits) != 6 {
+		return rgb{}, fmt.Errorf("invalid hexadecimal color %q", hex)
+	}
+
+	value, err := strconv.ParseUint(digits, 16, 32)
+	if err != nil {
+		return rgb{}, fmt.Errorf("invalid hexadecimal color %q", hex)
+	}
+
+	return rgb{int(value >> 16), int(value >> 8 & 0xff), int(value & 0xff)}, nil
+}
+
+// color256RGB returns the RGB value of a color of the palette of 256 colors.
+func color256RGB(index int) rgb {
+	switch {
+	case index < 16:
+		return basicColors[index]
+	case index < 232:
+		index -= 16
+		return rgb{cubeLevels[index/36], cubeLevels[index/6%6], cubeLevels[index%6]}
+	}
+
+	gray := 8 + (index-232)*10
+	return rgb{gray, gray, gray}
+}
+
+// distance returns the squared distance between two colors.
+func (c rgb) distance(other rgb) int {
+	r, g, b := c.r-other.r, c.g-other.g, c.b-other.b
+	return r*r + g*g + b*b
+}
+
+// to256 returns the index of the nearest color in the palette of 256
+// colors, among the ones of the color cube and the grayscale ramp.
+func (c rgb) to256() int {
+	nearestLevel := func(value int) int {
+		nearest := 0
+		for i, level := range cubeLevels {
+			if abs(level-value) < abs(cubeLevels[nearest]-value) {
+				nearest = i
+			}
+		}
+		return nearest
+	}
+
+	cube := 16 + 36*nearestLevel(c.r) + 6*nearestLevel(c.g) + nearestLevel(c.b)
+
+	gray := 232 + ((c.r+c.g+c.b)/3-3)/10
+	if gray < 232 {
+		gray = 232
+	}
+	if gray > 255 {
+		gray = 255
+	}
+
+	if c.distance(color256RGB(gray)) < c.distance(color256RGB(cube)) {
+		return gray
+	}
+	return cube
+}
+
+// toBasic returns the index of the nearest of the 16 basic colors.
+func (c rgb) toBasic() int {
+	nearest := 0
+	for i, basic := range basicColors {
+		if c.distance(basic) < c.distance(basicColors[nearest]) {
+			nearest = i
+		}
+	}
+	return nearest
+}
+
+// basicParam returns the SGR parameter of a basic color,
+// for the foreground or the background.
+func basicParam(index int, background bool) int {
+	param := 30 + index
+	if index >= 8 {
+		param = 90 + index - 8
+	}
+	if background {
+		param += 10
+	}
+	return param
+}
+
+// rgbParams returns the SGR parameters of an RGB color,
+// downsampled for the given profile.
+func rgbParams(c rgb, background bool, profile ColorProfile) []int {
+	extended := 38
+	if background {
+		extended = 48
+	}
+
+	switch profile {
+	case TrueColor:
+		return []int{extended, 2, c.r, c.g, c.b}
+	case ANSI256:
+		return []int{extended, 5, c.to256()}
+	}
+	return []int{basicParam(c.toBasic(), background)}
+}
+
+// color256Params returns the SGR parameters of a color of the
+// palette of 256 colors, downsampled for the given profile.
+func color256Params(index int, background bool, profile ColorProfile) []int {
+	if profile == ANSI {
+		if index < 16 {
+			return []int{basicParam(index, background)}
+		}
+		return []int{basicParam(color256RGB(index).toBasic(), background)}
+	}
+
+	if background {
+		return []int{48, 5, index}
+	}
+	return []int{38, 5, index}
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
@@ -0,0 +1,77 @@
+package style
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectColorProfile(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		colorterm string
+		term      string
+		expected  ColorProfile
+	}{
+		{
+			desc:     "no variables",
+			expected: ANSI,
+		},
+		{
+			desc:     "basic terminal",
+			term:     "xterm",
+			expected: ANSI,
+		},
+		{
+			desc:     "256 colors terminal",
+			term:     "xterm-256color",
+			expected: ANSI256,
+		},
+		{
+			desc:      "COLORTERM",
+			colorterm: "truecolor",
+			term:      "xterm-256color",
+			expected:  TrueColor,
+		},
+		{
+			desc:     "direct color terminal",
+			term:     "xterm-direct",
+			expected: TrueColor,
+		},
+	}
+
+	colorterm, term := os.Getenv("COLORTERM"), os.Getenv("TERM")
+	defer func() {
+		os.Setenv("COLORTERM", colorterm)
+		os.Setenv("TERM", term)
+	}()
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			require.NoError(t, os.Setenv("COLORTERM", test.colorterm))
+			require.NoError(t, os.Setenv("TERM", test.term))
+
+			assert.Equal(t, test.expected, DetectColorProfile())
+		})
+	}
+}
+
+func TestTo256(t *testing.T) {
+	testCases := []struct {
+		color    rgb
+		expected int
+	}{
+		{rgb{0, 0, 0}, 16},
+		{rgb{255, 255, 255}, 231},
+		{rgb{255, 0, 0}, 196},
+		{rgb{95, 135, 175}, 67},
+		{rgb{18, 18, 18}, 233},
+		{rgb{238, 238, 238}, 255},
+	}
+
+	for _, test := range testCases {
+		assert.Equal(t, test.expected, test.color.to256(), "%v", test.color)
+	}
+}
@@ -0,0 +1,323 @@
+package style
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/Ullaakut/disgo/internal/linediff"
+)
+
+// DefaultDiffContext is the number of unchanged lines that
+// are shown around changes, unless specified otherwise.
+const DefaultDiffContext = 3
+
+var (
+	diffRemoved   = Style{"red"}
+	diffAdded     = Style{"green"}
+	diffHunk      = Style{"cyan"}
+	diffLineNum   = Style{"faint"}
+	diffHighlight = "reverse"
+)
+
+// Diff renders the differences between two texts as a unified diff,
+// with line numbers, in which removed lines are red and added lines are
+// green. When a line is replaced by another, the part of it that
+// changed is highlighted. Since it implements fmt.Stringer, a diff can
+// be printed through a Terminal, using Infoln for example, right before
+// asking the user to confirm the changes.
+type Diff struct {
+	// Old and New are the texts that are compared.
+	Old, New string
+	// OldName and NewName are the names of the texts, such as file
+	// paths, which are shown in the header of the diff. The header
+	// is omitted if both are empty.
+	OldName, NewName string
+	// Context is the number of unchanged lines that
+	// are shown around changes.
+	Context int
+}
+
+// NewDiff creates a diff between two texts,
+// with DefaultDiffContext lines of context.
+func NewDiff(old, new string) *Diff {
+	return &Diff{
+		Old:     old,
+		New:     new,
+		Context: DefaultDiffContext,
+	}
+}
+
+// NewFileDiff creates a diff between the contents of two
+// files, with DefaultDiffContext lines of context.
+func NewFileDiff(oldPath, newPath string) (*Diff, error) {
+	old, err := ioutil.ReadFile(oldPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s: %v", oldPath, err)
+	}
+
+	new, err := ioutil.ReadFile(newPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s: %v", newPath, err)
+	}
+
+	diff := NewDiff(string(old), string(new))
+	diff.OldName = oldPath
+	diff.NewName = newPath
+	return diff, nil
+}
+
+// diffOp is a line of a diff, which is either unchanged, removed
+// from the old text or added to the new one. Line numbers start at 1,
+// and are 0 for lines that are not in the corresponding text.
+type diffOp struct {
+	kind    byte
+	oldLine int
+	newLine int
+	text    string
+	// Number of lines of each text that come before the line.
+	oldBefore, newBefore int
+}
+
+// Empty returns whether or not the texts are the same.
+func (d Diff) Empty() bool {
+	return d.Old == d.New
+}
+
+// String renders the diff, without a trailing newline. It
+// is empty if the texts are the same.
+func (d Diff) String() string {
+	if d.Empty() {
+		return ""
+	}
+
+	old, new := splitLines(d.Old), splitLines(d.New)
+	ops := diffOps(old, new)
+
+	// Line numbers are aligned on the widest one.
+	numWidth := len(strconv.Itoa(len(old)))
+	if w := len(strconv.Itoa(len(new))); w > numWidth {
+		numWidth = w
+	}
+
+	var lines []string
+	if d.OldName != "" || d.NewName != "" {
+		lines = append(lines, Important("--- "+d.OldName), Important("+++ "+d.NewName))
+	}
+
+	for _, hunk := range hunks(ops, d.Context) {
+		lines = append(lines, diffHunk.Sprint(hunkHeader(hunk)))
+		lines = append(lines, renderHunk(hunk, numWidth)...)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// splitLines splits a text into lines, ignoring its trailing newline.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// diffOps computes the lines that are unchanged, removed
+// and added between two texts.
+func diffOps(a, b []string) []diffOp {
+	changes := linediff.Lines(a, b)
+	ops := make([]diffOp, len(changes))
+
+	i, j := 0, 0
+	for k, change := range changes {
+		switch change.Kind {
+		case linediff.Equal:
+			ops[k] = diffOp{' ', i + 1, j + 1, a[i], i, j}
+			i++
+			j++
+		case linediff.Delete:
+			ops[k] = diffOp{'-', i + 1, 0, a[i], i, j}
+			i++
+		case linediff.Insert:
+			ops[k] = diffOp{'+', 0, j + 1, b[j], i, j}
+			j++
+		}
+	}
+	return ops
+}
+
+// hunks groups the changes of a diff along with the given number of
+// unchanged lines around them. Changes that are close enough for their
+// context to overlap are grouped in the same hunk.
+func hunks(ops []diffOp, context int) [][]diffOp {
+	if context < 0 {
+		context = 0
+	}
+
+	var (
+		groups     [][]diffOp
+		start, end = -1, -1
+	)
+	for i, op := range ops {
+		if op.kind == ' ' {
+			continue
+		}
+
+		from, to := i-context, i+context+1
+		if from < 0 {
+			from = 0
+		}
+		if to > len(ops) {
+			to = len(ops)
+		}
+
+		if start >= 0 && from <= end {
+			end = to
+			continue
+		}
+		if start >= 0 {
+			groups = append(groups, ops[start:end])
+		}
+		start, end = from, to
+	}
+	if start >= 0 {
+		groups = append(groups, ops[start:end])
+	}
+
+	return groups
+}
+
+// hunkHeader returns the `@@ -l,s +l,s @@` header of a hunk.
+func hunkHeader(hunk []diffOp) string {
+	var oldCount, newCount int
+	for _, op := range hunk {
+		if op.oldLine > 0 {
+			oldCount++
+		}
+		if op.newLine > 0 {
+			newCount++
+		}
+	}
+
+	// Like in unified diffs, empty ranges start on
+	// the line after which they would be.
+	oldStart, newStart := hunk[0].oldBefore, hunk[0].newBefore
+	if oldCount > 0 {
+		oldStart++
+	}
+	if newCount > 0 {
+		newStart++
+	}
+
+	return fmt.Sprintf("@@ -%s +%s @@", hunkRange(oldStart, oldCount), hunkRange(newStart, newCount))
+}
+
+func hunkRange(start, count int) string {
+	if count == 1 {
+		return strconv.Itoa(start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+// renderHunk renders the lines of a hunk, with their line numbers.
+// Lines that are removed and immediately replaced by as many added
+// lines are paired, and the parts of them that changed highlighted.
+func renderHunk(hunk []diffOp, numWidth int) []string {
+	var lines []string
+	for i := 0; i < len(hunk); {
+		if hunk[i].kind == ' ' {
+			lines = append(lines, renderDiffLine(hunk[i], hunk[i].text, numWidth))
+			i++
+			continue
+		}
+
+		// Find the block of removed lines and the block
+		// of added lines that follows it.
+		removedEnd := i
+		for removedEnd < len(hunk) && hunk[removedEnd].kind == '-' {
+			removedEnd++
+		}
+		addedEnd := removedEnd
+		for addedEnd < len(hunk) && hunk[addedEnd].kind == '+' {
+			addedEnd++
+		}
+		removed, added := hunk[i:removedEnd], hunk[removedEnd:addedEnd]
+
+		removedTexts := make([]string, len(removed))
+		addedTexts := make([]string, len(added))
+		for j, op := range removed {
+			removedTexts[j] = diffRemoved.Sprint(op.text)
+		}
+		for j, op := range added {
+			addedTexts[j] = diffAdded.Sprint(op.text)
+		}
+		if len(removed) == len(added) {
+			for j := range removed {
+				removedTexts[j], addedTexts[j] = highlightChanges(removed[j].text, added[j].text)
+			}
+		}
+
+		for j, op := range removed {
+			lines = append(lines, renderDiffLine(op, removedTexts[j], numWidth))
+		}
+		for j, op := range added {
+			lines = append(lines, renderDiffLine(op, addedTexts[j], numWidth))
+		}
+		i = addedEnd
+	}
+	return lines
+}
+
+// renderDiffLine renders a line of a diff, whose
+// text was already styled, with its line numbers.
+func renderDiffLine(op diffOp, text string, numWidth int) string {
+	number := func(n int) string {
+		if n == 0 {
+			return strings.Repeat(" ", numWidth)
+		}
+		return fmt.Sprintf("%*d", numWidth, n)
+	}
+
+	marker := string(op.kind)
+	switch op.kind {
+	case '-':
+		marker = diffRemoved.Sprint(marker)
+	case '+':
+		marker = diffAdded.Sprint(marker)
+	}
+
+	line := diffLineNum.Sprint(number(op.oldLine)+" "+number(op.newLine)) + " " + marker + " " + text
+	return strings.TrimRight(line, " ")
+}
+
+// highlightChanges styles a removed line and the line that replaces it,
+// highlighting the part of them that is between their common prefix and
+// their common suffix.
+func highlightChanges(old, new string) (string, string) {
+	a, b := []rune(old), []rune(new)
+
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	styled := func(s Style, part []rune) string {
+		if len(part) == 0 {
+			return ""
+		}
+		return s.Sprint(string(part))
+	}
+
+	highlight := func(line []rune, base Style) string {
+		changed := append(append(Style(nil), base...), diffHighlight)
+		return styled(base, line[:prefix]) +
+			styled(changed, line[prefix:len(line)-suffix]) +
+			styled(base, line[len(line)-suffix:])
+	}
+
+	return highlight(a, diffRemoved), highlight(b, diffAdded)
+}
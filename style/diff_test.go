@@ -0,0 +1,129 @@
+package style
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	old := "name: app\nport: 80\ndebug: false\nreplicas: 1\nregion: eu\nzone: a\nimage: app:1.0\n"
+
+	testCases := []struct {
+		desc     string
+		diff     *Diff
+		expected string
+	}{
+		{
+			desc:     "same texts",
+			diff:     NewDiff(old, old),
+			expected: "",
+		},
+		{
+			desc: "changed line",
+			diff: NewDiff(old, "name: app\nport: 8080\ndebug: false\nreplicas: 1\nregion: eu\nzone: a\nimage: app:1.0\n"),
+			expected: "@@ -1,5 +1,5 @@\n" +
+				"1 1   name: app\n" +
+				"2   - port: 80\n" +
+				"  2 + port: 8080\n" +
+				"3 3   debug: false\n" +
+				"4 4   replicas: 1\n" +
+				"5 5   region: eu",
+		},
+		{
+			desc: "no context and names",
+			diff: &Diff{
+				Old:     old,
+				New:     "name: app\nport: 80\ndebug: false\nreplicas: 1\nregion: eu\nzone: a\nzone: b\n",
+				OldName: "a/config.yaml",
+				NewName: "b/config.yaml",
+			},
+			expected: "--- a/config.yaml\n" +
+				"+++ b/config.yaml\n" +
+				"@@ -7 +7 @@\n" +
+				"7   - image: app:1.0\n" +
+				"  7 + zone: b",
+		},
+		{
+			desc: "separate hunks",
+			diff: &Diff{
+				Old:     old,
+				New:     "name: web\nport: 80\ndebug: false\nreplicas: 1\nregion: eu\nzone: a\nimage: app:2.0\n",
+				Context: 1,
+			},
+			expected: "@@ -1,2 +1,2 @@\n" +
+				"1   - name: app\n" +
+				"  1 + name: web\n" +
+				"2 2   port: 80\n" +
+				"@@ -6,2 +6,2 @@\n" +
+				"6 6   zone: a\n" +
+				"7   - image: app:1.0\n" +
+				"  7 + image: app:2.0",
+		},
+		{
+			desc: "added lines",
+			diff: &Diff{
+				Old: "a\nb\n",
+				New: "a\nb\nc\nd\n",
+			},
+			expected: "@@ -2,0 +3,2 @@\n" +
+				"  3 + c\n" +
+				"  4 + d",
+		},
+		{
+			desc: "removed lines",
+			diff: &Diff{
+				Old: "a\nb\nc\n",
+				New: "c\n",
+			},
+			expected: "@@ -1,2 +0,0 @@\n" +
+				"1   - a\n" +
+				"2   - b",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.diff.String())
+		})
+	}
+}
+
+func TestDiffHighlighting(t *testing.T) {
+	color.NoColor = false
+	defer func() {
+		color.NoColor = true
+	}()
+
+	diff := &Diff{Old: "port: 80\n", New: "port: 8080\n"}
+
+	assert.Equal(t, "\x1b[36m@@ -1 +1 @@\x1b[0m\n"+
+		"\x1b[2m1  \x1b[0m \x1b[31m-\x1b[0m \x1b[31mport: 80\x1b[0m\n"+
+		"\x1b[2m  1\x1b[0m \x1b[32m+\x1b[0m \x1b[32mport: 80\x1b[0m\x1b[32;7m80\x1b[0m", diff.String())
+}
+
+func TestNewFileDiff(t *testing.T) {
+	dir, err := ioutil.TempDir("", "disgo-diff")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	oldPath, newPath := filepath.Join(dir, "old.txt"), filepath.Join(dir, "new.txt")
+	require.NoError(t, ioutil.WriteFile(oldPath, []byte("hello\n"), 0644))
+	require.NoError(t, ioutil.WriteFile(newPath, []byte("world\n"), 0644))
+
+	diff, err := NewFileDiff(oldPath, newPath)
+	require.NoError(t, err)
+	assert.Equal(t, "--- "+oldPath+"\n"+
+		"+++ "+newPath+"\n"+
+		"@@ -1 +1 @@\n"+
+		"1   - hello\n"+
+		"  1 + world", diff.String())
+
+	_, err = NewFileDiff(filepath.Join(dir, "missing.txt"), newPath)
+	assert.Error(t, err)
+}
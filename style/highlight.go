@@ -0,0 +1,234 @@
+package style
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Syntax is a set of styles used to highlight data,
+// such as JSON and YAML documents.
+type Syntax struct {
+	// Key is the style of the keys of objects.
+	Key Style `json:"key" yaml:"key"`
+	// String is the style of strings.
+	String Style `json:"string" yaml:"string"`
+	// Number is the style of numbers.
+	Number Style `json:"number" yaml:"number"`
+	// Bool is the style of booleans.
+	Bool Style `json:"bool" yaml:"bool"`
+	// Null is the style of null values.
+	Null Style `json:"null" yaml:"null"`
+}
+
+// JSON pretty-prints a value as indented JSON, highlighted with the
+// syntax styles of the default theme, regardless of the theme of any
+// terminal. Use the JSON method of a terminal, or the Syntax of a
+// theme, to follow another theme. See Syntax.JSON.
+func JSON(v interface{}) string {
+	return DefaultTheme.Syntax.JSON(v)
+}
+
+// YAML pretty-prints a value as YAML, highlighted with the syntax
+// styles of the default theme, regardless of the theme of any
+// terminal. Use the YAML method of a terminal, or the Syntax of a
+// theme, to follow another theme. See Syntax.YAML.
+func YAML(v interface{}) string {
+	return DefaultTheme.Syntax.YAML(v)
+}
+
+// JSON pretty-prints a value as indented JSON, and highlights its keys,
+// strings, numbers, booleans and null values. Byte slices and
+// json.RawMessage values that contain JSON, such as the bodies of API
+// responses, are indented as they are. Values that can't be marshaled
+// are formatted like fmt.Sprintf's %+v verb would format them. The
+// result has no trailing newline.
+func (s Syntax) JSON(v interface{}) string {
+	content, err := indentJSON(v)
+	if err != nil {
+		return fmt.Sprintf("%+v", v)
+	}
+	return s.highlightJSON(content)
+}
+
+// YAML pretty-prints a value as YAML, and highlights its keys, strings,
+// numbers, booleans and null values. Byte slices and json.RawMessage
+// values that contain JSON are converted to YAML. Values that can't be
+// marshaled are formatted like fmt.Sprintf's %+v verb would format
+// them. The result has no trailing newline.
+func (s Syntax) YAML(v interface{}) string {
+	if raw, ok := rawJSON(v); ok {
+		// JSON documents are valid YAML documents.
+		var document yaml.MapSlice
+		if err := yaml.Unmarshal(raw, &document); err == nil {
+			v = document
+		} else {
+			var value interface{}
+			if err := yaml.Unmarshal(raw, &value); err == nil {
+				v = value
+			}
+		}
+	}
+
+	content, err := yaml.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%+v", v)
+	}
+	return s.highlightYAML(strings.TrimSuffix(string(content), "\n"))
+}
+
+// rawJSON returns the content of byte slices and
+// json.RawMessage values, if it is valid JSON.
+func rawJSON(v interface{}) ([]byte, bool) {
+	var raw []byte
+	switch value := v.(type) {
+	case json.RawMessage:
+		raw = value
+	case []byte:
+		raw = value
+	default:
+		return nil, false
+	}
+	return raw, json.Valid(raw)
+}
+
+// indentJSON marshals a value as indented JSON, without
+// escaping HTML characters, which makes it easier to read.
+func indentJSON(v interface{}) (string, error) {
+	var buf bytes.Buffer
+	if raw, ok := rawJSON(v); ok {
+		if err := json.Indent(&buf, raw, "", "  "); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	}
+
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// highlightJSON highlights the tokens of a valid JSON document.
+func (s Syntax) highlightJSON(content string) string {
+	var result strings.Builder
+	for i := 0; i < len(content); {
+		switch c := content[i]; {
+		case c == '"':
+			end := i + 1
+			for end < len(content) && content[end] != '"' {
+				if content[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end++
+
+			// Strings that are followed by a colon are keys.
+			next := strings.TrimLeft(content[end:], " \t\r\n")
+			if strings.HasPrefix(next, ":") {
+				result.WriteString(s.Key.Sprint(content[i:end]))
+			} else {
+				result.WriteString(s.String.Sprint(content[i:end]))
+			}
+			i = end
+		case c == '-' || (c >= '0' && c <= '9'):
+			end := i + 1
+			for end < len(content) && strings.IndexByte("0123456789.eE+-", content[end]) >= 0 {
+				end++
+			}
+			result.WriteString(s.Number.Sprint(content[i:end]))
+			i = end
+		case strings.HasPrefix(content[i:], "true"):
+			result.WriteString(s.Bool.Sprint("true"))
+			i += len("true")
+		case strings.HasPrefix(content[i:], "false"):
+			result.WriteString(s.Bool.Sprint("false"))
+			i += len("false")
+		case strings.HasPrefix(content[i:], "null"):
+			result.WriteString(s.Null.Sprint("null"))
+			i += len("null")
+		default:
+			result.WriteByte(c)
+			i++
+		}
+	}
+	return result.String()
+}
+
+var (
+	// yamlLinePattern splits a line of YAML into its indentation and list
+	// markers, its key if it has one, and its value.
+	yamlLinePattern = regexp.MustCompile(`^(\s*(?:- )*)(?:("(?:[^"\\]|\\.)*"|'(?:[^']|'')*'|[^\s"'#-][^:#]*?|-[^\s:#][^:#]*?)(:)(?:\s|$))?(.*)$`)
+	// yamlNumberPattern matches YAML numbers.
+	yamlNumberPattern = regexp.MustCompile(`^[-+]?(?:\d[\d_]*(?:\.\d*)?(?:[eE][-+]?\d+)?|\.\d+|0x[0-9a-fA-F]+|\.inf|\.Inf|\.nan|\.NaN)$`)
+)
+
+// highlightYAML highlights the keys and scalar values of a YAML
+// document, such as the ones produced by yaml.Marshal.
+func (s Syntax) highlightYAML(content string) string {
+	lines := strings.Split(content, "\n")
+
+	// Indentation of the block scalar that the current
+	// lines are part of, or -1 if they are not.
+	blockIndent := -1
+
+	for i, line := range lines {
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		if blockIndent >= 0 {
+			if indent > blockIndent || strings.TrimSpace(line) == "" {
+				lines[i] = line[:indent] + s.String.Sprint(line[indent:])
+				continue
+			}
+			blockIndent = -1
+		}
+
+		match := yamlLinePattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		prefix, key, colon, value := match[1], match[2], match[3], strings.TrimSpace(match[4])
+
+		var highlighted strings.Builder
+		highlighted.WriteString(prefix)
+		if colon != "" {
+			highlighted.WriteString(s.Key.Sprint(key) + ":")
+			if value != "" {
+				highlighted.WriteString(" ")
+			}
+		}
+
+		if strings.HasPrefix(value, "|") || strings.HasPrefix(value, ">") {
+			blockIndent = indent
+			highlighted.WriteString(value)
+		} else {
+			highlighted.WriteString(s.yamlScalar(value))
+		}
+
+		lines[i] = highlighted.String()
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// yamlScalar highlights a scalar YAML value according to its type.
+func (s Syntax) yamlScalar(value string) string {
+	switch {
+	case value == "", value == "[]", value == "{}":
+		return value
+	case value == "null" || value == "~":
+		return s.Null.Sprint(value)
+	case value == "true" || value == "false":
+		return s.Bool.Sprint(value)
+	case yamlNumberPattern.MatchString(value):
+		return s.Number.Sprint(value)
+	}
+	return s.String.Sprint(value)
+}
@@ -0,0 +1,152 @@
+package style
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+)
+
+type highlightResource struct {
+	Name    string   `json:"name" yaml:"name"`
+	Port    int      `json:"port" yaml:"port"`
+	Ratio   float64  `json:"ratio" yaml:"ratio"`
+	Enabled bool     `json:"enabled" yaml:"enabled"`
+	Owner   *string  `json:"owner" yaml:"owner"`
+	Tags    []string `json:"tags" yaml:"tags"`
+	Script  string   `json:"script" yaml:"script"`
+}
+
+func TestJSON(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		value    interface{}
+		expected string
+	}{
+		{
+			desc:  "struct",
+			value: highlightResource{Name: "<db>", Port: 5432, Ratio: -0.5, Enabled: true, Tags: []string{"a"}},
+			expected: `{
+  "name": "<db>",
+  "port": 5432,
+  "ratio": -0.5,
+  "enabled": true,
+  "owner": null,
+  "tags": [
+    "a"
+  ],
+  "script": ""
+}`,
+		},
+		{
+			desc:     "raw JSON",
+			value:    []byte(`{"b":1,"a":[true,null]}`),
+			expected: "{\n  \"b\": 1,\n  \"a\": [\n    true,\n    null\n  ]\n}",
+		},
+		{
+			desc:     "raw message",
+			value:    json.RawMessage(`"hello"`),
+			expected: `"hello"`,
+		},
+		{
+			desc:     "invalid raw JSON is marshaled as bytes",
+			value:    []byte("hi"),
+			expected: `"aGk="`,
+		},
+		{
+			desc:     "unmarshalable value",
+			value:    math.Inf(1),
+			expected: "+Inf",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, JSON(test.value))
+		})
+	}
+}
+
+func TestJSONHighlighting(t *testing.T) {
+	color.NoColor = false
+	defer func() {
+		color.NoColor = true
+	}()
+
+	syntax := Syntax{
+		Key:    Style{"blue"},
+		String: Style{"green"},
+		Number: Style{"yellow"},
+		Bool:   Style{"magenta"},
+		Null:   Style{"faint"},
+	}
+
+	assert.Equal(t, "{\n"+
+		"  \x1b[34m\"key \\\"quoted\\\"\"\x1b[0m: \x1b[32m\"value\"\x1b[0m,\n"+
+		"  \x1b[34m\"list\"\x1b[0m: [\n"+
+		"    \x1b[33m-1.5e+06\x1b[0m,\n"+
+		"    \x1b[35mfalse\x1b[0m,\n"+
+		"    \x1b[2mnull\x1b[0m\n"+
+		"  ]\n"+
+		"}", syntax.JSON([]byte(`{"key \"quoted\"": "value", "list": [-1.5e+06, false, null]}`)))
+}
+
+func TestYAML(t *testing.T) {
+	color.NoColor = false
+	defer func() {
+		color.NoColor = true
+	}()
+
+	syntax := Syntax{
+		Key:    Style{"blue"},
+		String: Style{"green"},
+		Number: Style{"yellow"},
+		Bool:   Style{"magenta"},
+		Null:   Style{"faint"},
+	}
+	key := func(k string) string { return "\x1b[34m" + k + "\x1b[0m:" }
+	str := func(v string) string { return "\x1b[32m" + v + "\x1b[0m" }
+
+	testCases := []struct {
+		desc     string
+		value    interface{}
+		expected string
+	}{
+		{
+			desc:  "struct",
+			value: highlightResource{Name: "db", Port: 5432, Ratio: 0.5, Enabled: true, Tags: []string{"a", "b: c"}, Script: "line 1\nline 2\n"},
+			expected: key("name") + " " + str("db") + "\n" +
+				key("port") + " \x1b[33m5432\x1b[0m\n" +
+				key("ratio") + " \x1b[33m0.5\x1b[0m\n" +
+				key("enabled") + " \x1b[35mtrue\x1b[0m\n" +
+				key("owner") + " \x1b[2mnull\x1b[0m\n" +
+				key("tags") + "\n" +
+				"- " + str("a") + "\n" +
+				"- " + str("'b: c'") + "\n" +
+				key("script") + " |\n" +
+				"  " + str("line 1") + "\n" +
+				"  " + str("line 2"),
+		},
+		{
+			desc:  "raw JSON keeps its order",
+			value: []byte(`{"b": {"c": [1]}, "a": "x"}`),
+			expected: key("b") + "\n" +
+				"  " + key("c") + "\n" +
+				"  - \x1b[33m1\x1b[0m\n" +
+				key("a") + " " + str("x"),
+		},
+		{
+			desc:     "raw JSON array",
+			value:    []byte(`["x", true]`),
+			expected: "- " + str("x") + "\n- \x1b[35mtrue\x1b[0m",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, syntax.YAML(test.value))
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package style
+
+import (
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// Hyperlinks is whether or not the terminal supports OSC 8 hyperlinks.
+// It is detected from the environment when the program starts, using
+// SupportsHyperlinks, and can be overridden.
+var Hyperlinks = SupportsHyperlinks()
+
+// hyperlinkPrograms are the values of TERM_PROGRAM
+// of terminals that support hyperlinks.
+var hyperlinkPrograms = map[string]bool{
+	"iterm.app": true,
+	"wezterm":   true,
+	"vscode":    true,
+	"hyper":     true,
+	"ghostty":   true,
+	"tabby":     true,
+}
+
+// hyperlinkTerms are substrings of the values of TERM
+// of terminals that support hyperlinks.
+var hyperlinkTerms = []string{"kitty", "alacritty", "foot", "ghostty", "wezterm"}
+
+// SupportsHyperlinks returns whether or not the terminal is known to
+// support OSC 8 hyperlinks, based on the environment variables that
+// terminal emulators set. Setting FORCE_HYPERLINK to 1 or 0 enables or
+// disables them regardless of the terminal.
+func SupportsHyperlinks() bool {
+	if force := os.Getenv("FORCE_HYPERLINK"); force != "" {
+		enabled, err := strconv.ParseBool(force)
+		return err == nil && enabled
+	}
+
+	term := strings.ToLower(os.Getenv("TERM"))
+	if term == "dumb" {
+		return false
+	}
+
+	if hyperlinkPrograms[strings.ToLower(os.Getenv("TERM_PROGRAM"))] {
+		return true
+	}
+	for _, name := range hyperlinkTerms {
+		if strings.Contains(term, name) {
+			return true
+		}
+	}
+
+	// Windows Terminal, kitty and DomTerm set these variables.
+	for _, name := range []string{"WT_SESSION", "KITTY_WINDOW_ID", "DOMTERM"} {
+		if os.Getenv(name) != "" {
+			return true
+		}
+	}
+
+	// Terminals based on VTE, such as GNOME Terminal,
+	// support hyperlinks since version 0.50.
+	if version, err := strconv.Atoi(os.Getenv("VTE_VERSION")); err == nil && version >= 5000 {
+		return true
+	}
+
+	return false
+}
+
+// Hyperlink renders a clickable link to the given URL, with the given
+// text, using an OSC 8 escape sequence. On terminals that are not known
+// to support them according to Hyperlinks, or when colors are disabled,
+// it renders the text followed by the URL in parentheses instead. If
+// the text is empty or the same as the URL, only the URL is rendered.
+// The text can be styled, using Link for example. Like other escape
+// sequences, hyperlinks are removed by Strip, which leaves their text,
+// and StripHyperlinks rewrites them as their fallback.
+func Hyperlink(url, text string) string {
+	if text == "" {
+		text = url
+	}
+
+	if !Hyperlinks || color.NoColor {
+		return hyperlinkFallback(url, text)
+	}
+
+	return "\x1b]8;;" + url + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
+
+// hyperlinkFallback renders a hyperlink for
+// terminals that don't support them.
+func hyperlinkFallback(url, text string) string {
+	if url == "" || Strip(text) == url {
+		return text
+	}
+	return text + " (" + url + ")"
+}
+
+// hyperlinkPattern matches the OSC 8 sequences that open hyperlinks,
+// whose URL is captured, and close them, whose URL is empty.
+var hyperlinkPattern = regexp.MustCompile(`\x1b\]8;[^;\x07\x1b]*;([^\x07\x1b]*)(?:\x07|\x1b\\)`)
+
+// StripHyperlinks rewrites the OSC 8 hyperlinks of a message as the text
+// followed by the URL in parentheses, like Hyperlink renders them on
+// terminals that don't support them, and leaves other escape sequences
+// as they are. It is meant to be used before Strip, so that the URLs of
+// hyperlinks are not lost on outputs such as log files.
+func StripHyperlinks(message string) string {
+	matches := hyperlinkPattern.FindAllStringSubmatchIndex(message, -1)
+	if len(matches) == 0 {
+		return message
+	}
+
+	var (
+		result strings.Builder
+		url    string
+		last   int
+	)
+	for _, match := range matches {
+		// The text before the sequence is either part of the
+		// hyperlink that is open, or outside of any hyperlink.
+		text := message[last:match[0]]
+		if url != "" {
+			text = hyperlinkFallback(url, text)
+		}
+		result.WriteString(text)
+
+		url = message[match[2]:match[3]]
+		last = match[1]
+	}
+
+	// Hyperlinks that are not closed end with the message.
+	text := message[last:]
+	if url != "" {
+		text = hyperlinkFallback(url, text)
+	}
+	result.WriteString(text)
+
+	return result.String()
+}
@@ -0,0 +1,204 @@
+package style
+
+import (
+	"os"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSupportsHyperlinks(t *testing.T) {
+	names := []string{"FORCE_HYPERLINK", "TERM", "TERM_PROGRAM", "WT_SESSION", "KITTY_WINDOW_ID", "DOMTERM", "VTE_VERSION"}
+	saved := make(map[string]string)
+	for _, name := range names {
+		saved[name] = os.Getenv(name)
+	}
+	defer func() {
+		for name, value := range saved {
+			os.Setenv(name, value)
+		}
+	}()
+
+	testCases := []struct {
+		desc     string
+		env      map[string]string
+		expected bool
+	}{
+		{
+			desc:     "unknown terminal",
+			env:      map[string]string{"TERM": "xterm-256color"},
+			expected: false,
+		},
+		{
+			desc:     "iTerm2",
+			env:      map[string]string{"TERM_PROGRAM": "iTerm.app"},
+			expected: true,
+		},
+		{
+			desc:     "kitty",
+			env:      map[string]string{"TERM": "xterm-kitty"},
+			expected: true,
+		},
+		{
+			desc:     "Windows Terminal",
+			env:      map[string]string{"WT_SESSION": "1"},
+			expected: true,
+		},
+		{
+			desc:     "recent VTE",
+			env:      map[string]string{"VTE_VERSION": "6003"},
+			expected: true,
+		},
+		{
+			desc:     "old VTE",
+			env:      map[string]string{"VTE_VERSION": "4205"},
+			expected: false,
+		},
+		{
+			desc:     "dumb terminal",
+			env:      map[string]string{"TERM": "dumb", "TERM_PROGRAM": "vscode"},
+			expected: false,
+		},
+		{
+			desc:     "forced",
+			env:      map[string]string{"FORCE_HYPERLINK": "1"},
+			expected: true,
+		},
+		{
+			desc:     "forced off",
+			env:      map[string]string{"FORCE_HYPERLINK": "0", "TERM_PROGRAM": "WezTerm"},
+			expected: false,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			for _, name := range names {
+				require.NoError(t, os.Setenv(name, test.env[name]))
+			}
+
+			assert.Equal(t, test.expected, SupportsHyperlinks())
+		})
+	}
+}
+
+func TestHyperlink(t *testing.T) {
+	defer func(enabled bool) {
+		Hyperlinks = enabled
+		color.NoColor = true
+	}(Hyperlinks)
+
+	testCases := []struct {
+		desc       string
+		hyperlinks bool
+		noColor    bool
+		url        string
+		text       string
+		expected   string
+	}{
+		{
+			desc:       "supported",
+			hyperlinks: true,
+			url:        "https://example.com",
+			text:       "docs",
+			expected:   "\x1b]8;;https://example.com\x1b\\docs\x1b]8;;\x1b\\",
+		},
+		{
+			desc:       "supported without text",
+			hyperlinks: true,
+			url:        "https://example.com",
+			expected:   "\x1b]8;;https://example.com\x1b\\https://example.com\x1b]8;;\x1b\\",
+		},
+		{
+			desc:     "unsupported",
+			url:      "https://example.com",
+			text:     "docs",
+			expected: "docs (https://example.com)",
+		},
+		{
+			desc:     "unsupported without text",
+			url:      "https://example.com",
+			expected: "https://example.com",
+		},
+		{
+			desc:       "colors disabled",
+			hyperlinks: true,
+			noColor:    true,
+			url:        "https://example.com",
+			text:       "docs",
+			expected:   "docs (https://example.com)",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			Hyperlinks = test.hyperlinks
+			color.NoColor = test.noColor
+
+			assert.Equal(t, test.expected, Hyperlink(test.url, test.text))
+		})
+	}
+}
+
+func TestHyperlinkStripping(t *testing.T) {
+	defer func(enabled bool) {
+		Hyperlinks = enabled
+		color.NoColor = true
+	}(Hyperlinks)
+	Hyperlinks = true
+	color.NoColor = false
+
+	link := "see \x1b]8;;https://example.com\x1b\\\x1b[1mdocs\x1b[0m\x1b]8;;\x1b\\ now"
+
+	assert.Equal(t, "see docs now", Strip(link))
+	assert.Equal(t, "docs", Strip("\x1b]8;id=1;https://example.com\x07docs\x1b]8;;\x07"))
+	assert.Equal(t, 12, Width(link))
+
+	// Hyperlinks that are cut are closed.
+	assert.Equal(t, "see \x1b]8;;https://example.com\x1b\\\x1b[1mdo…\x1b]8;;\x1b\\\x1b[0m", Truncate(link, 7, Ellipsis))
+	assert.Equal(t, "see \x1b]8;;https://example.com\x1b\\\x1b[1mdocs\x1b[0m\x1b]8;;\x1b\\\nnow", Wrap(link, 9))
+	assert.Equal(t, "\x1b]8;;https://example.com\x1b\\a very\x1b]8;;\x1b\\\n\x1b]8;;https://example.com\x1b\\long link\x1b]8;;\x1b\\",
+		Wrap(Hyperlink("https://example.com", "a very long link"), 10))
+}
+
+func TestStripHyperlinks(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		message  string
+		expected string
+	}{
+		{
+			desc:     "no hyperlink",
+			message:  "\x1b[1mplain\x1b[0m",
+			expected: "\x1b[1mplain\x1b[0m",
+		},
+		{
+			desc:     "styled text",
+			message:  "see \x1b]8;;https://example.com\x1b\\\x1b[1mdocs\x1b[0m\x1b]8;;\x1b\\ now",
+			expected: "see \x1b[1mdocs\x1b[0m (https://example.com) now",
+		},
+		{
+			desc:     "BEL terminators and parameters",
+			message:  "\x1b]8;id=1;https://a.com\x07a\x1b]8;;\x07 and \x1b]8;;https://b.com\x07b\x1b]8;;\x07",
+			expected: "a (https://a.com) and b (https://b.com)",
+		},
+		{
+			desc:     "text that is the URL",
+			message:  "\x1b]8;;https://example.com\x1b\\https://example.com\x1b]8;;\x1b\\",
+			expected: "https://example.com",
+		},
+		{
+			desc:     "unclosed hyperlink",
+			message:  "\x1b]8;;https://example.com\x1b\\docs",
+			expected: "docs (https://example.com)",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, StripHyperlinks(test.message))
+		})
+	}
+}
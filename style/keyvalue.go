@@ -0,0 +1,370 @@
+package style
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// KeyValueFormat represents the format in which key-value pairs are rendered.
+type KeyValueFormat int
+
+const (
+	// KeyValueText renders pairs as aligned `key: value` lines, for humans.
+	KeyValueText KeyValueFormat = iota
+	// KeyValueJSON renders pairs as an indented JSON object, without
+	// styles, which is convenient for commands that support an
+	// `--output json` flag.
+	KeyValueJSON
+)
+
+// minValueWidth is the width under which values are not wrapped.
+const minValueWidth = 10
+
+// cyclePlaceholder is rendered instead of values that contain
+// themselves, such as a node that points to its own parent.
+const cyclePlaceholder = "<cycle>"
+
+// Pair is a key associated with a value.
+type Pair struct {
+	Key   string
+	Value interface{}
+}
+
+// KeyValue renders the details of a resource as `key: value` pairs, with
+// aligned values. Values that are maps, structs or KeyValues are rendered
+// as nested pairs, indented under their key. Since it implements
+// fmt.Stringer, a KeyValue can be printed through a Terminal, using
+// Infoln for example, so that it is queued during steps.
+type KeyValue struct {
+	// Pairs to render, in order.
+	Pairs []Pair
+
+	// MaxWidth is the maximum number of columns that the pairs can
+	// take, which is usually the width of the terminal. Longer values
+	// are wrapped, and their continuation lines are aligned with their
+	// first line. If it is 0, values are not wrapped.
+	MaxWidth int
+	// Format in which the pairs are rendered.
+	Format KeyValueFormat
+
+	// Reference to the value from which the pairs were created by
+	// KeyValueOf, so that values that contain it are not rendered
+	// again.
+	ref reference
+}
+
+// NewKeyValue creates an empty KeyValue.
+func NewKeyValue() *KeyValue {
+	return &KeyValue{}
+}
+
+// KeyValueOf creates a KeyValue from the fields of a struct or the
+// entries of a map. Struct fields are named after their json tag if
+// they have one, and fields tagged with `json:"-"` are skipped. Map
+// entries are sorted by key. Other values result in an empty KeyValue.
+// Pointers and maps that contain themselves, such as a node that points
+// to its parent, are rendered as `<cycle>` where they repeat.
+func KeyValueOf(v interface{}) *KeyValue {
+	pairs, ref, _ := nestedPairs(v)
+	return &KeyValue{
+		Pairs: pairs,
+		ref:   ref,
+	}
+}
+
+// Add adds a pair to the KeyValue, and returns it.
+func (kv *KeyValue) Add(key string, value interface{}) *KeyValue {
+	kv.Pairs = append(kv.Pairs, Pair{Key: key, Value: value})
+	return kv
+}
+
+// String renders the pairs, without a trailing newline.
+func (kv KeyValue) String() string {
+	if kv.Format == KeyValueJSON {
+		return kv.json()
+	}
+
+	var lines []string
+	kv.render(&lines, kv.Pairs, "", kv.path())
+	return strings.Join(lines, "\n")
+}
+
+// path returns the references of the values that are being
+// rendered, which initially only contains the KeyValue's own.
+func (kv KeyValue) path() map[reference]bool {
+	path := make(map[reference]bool)
+	if kv.ref.valid() {
+		path[kv.ref] = true
+	}
+	return path
+}
+
+// render appends the lines of the given pairs, prefixed with indent.
+// Nested pairs whose reference is already in the path are values that
+// contain themselves, and are rendered as a placeholder.
+func (kv KeyValue) render(lines *[]string, pairs []Pair, indent string, path map[reference]bool) {
+	keyWidth := 0
+	for _, pair := range pairs {
+		if w := Width(pair.Key) + 1; w > keyWidth {
+			keyWidth = w
+		}
+	}
+
+	for _, pair := range pairs {
+		key := Important(pair.Key) + ":"
+
+		if nested, ref, ok := nestedPairs(pair.Value); ok {
+			if path[ref] {
+				*lines = append(*lines, indent+key+" "+cyclePlaceholder)
+				continue
+			}
+
+			*lines = append(*lines, indent+key)
+			if ref.valid() {
+				path[ref] = true
+			}
+			kv.render(lines, nested, indent+"  ", path)
+			delete(path, ref)
+			continue
+		}
+
+		prefix := indent + pad(key, keyWidth) + " "
+		hanging := strings.Repeat(" ", Width(prefix))
+
+		value := formatValue(pair.Value)
+		if kv.MaxWidth > 0 {
+			available := kv.MaxWidth - Width(prefix)
+			if available < minValueWidth {
+				available = minValueWidth
+			}
+			value = Wrap(value, available)
+		}
+
+		for i, line := range strings.Split(value, "\n") {
+			if i == 0 {
+				*lines = append(*lines, strings.TrimRight(prefix+line, " "))
+			} else {
+				*lines = append(*lines, strings.TrimRight(hanging+line, " "))
+			}
+		}
+	}
+}
+
+func (kv KeyValue) json() string {
+	var buf bytes.Buffer
+	writeJSON(&buf, kv.Pairs, kv.path())
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, buf.Bytes(), "", "  "); err != nil {
+		return buf.String()
+	}
+	return indented.String()
+}
+
+// writeJSON writes pairs as a compact JSON object, in order, with
+// values that contain themselves written as a placeholder.
+func writeJSON(buf *bytes.Buffer, pairs []Pair, path map[reference]bool) {
+	buf.WriteString("{")
+	for i, pair := range pairs {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+
+		key, _ := json.Marshal(Strip(StripHyperlinks(pair.Key)))
+		buf.Write(key)
+		buf.WriteString(":")
+
+		if nested, ref, ok := nestedPairs(pair.Value); ok {
+			if path[ref] {
+				buf.WriteString(`"` + cyclePlaceholder + `"`)
+				continue
+			}
+
+			if ref.valid() {
+				path[ref] = true
+			}
+			writeJSON(buf, nested, path)
+			delete(path, ref)
+			continue
+		}
+
+		value := pair.Value
+		if s, ok := value.(string); ok {
+			value = Strip(StripHyperlinks(s))
+		}
+
+		// Values that can't be marshaled are written as
+		// they would be displayed.
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			encoded, _ = json.Marshal(Strip(StripHyperlinks(formatValue(value))))
+		}
+		buf.Write(encoded)
+	}
+	buf.WriteString("}")
+}
+
+// formatValue formats a value that is not rendered as nested pairs.
+// Slices are rendered as comma-separated lists, and nil as an empty
+// string.
+func formatValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		if _, ok := value.(fmt.Stringer); !ok && rv.Type().Elem().Kind() != reflect.Uint8 {
+			items := make([]string, rv.Len())
+			for i := range items {
+				items[i] = formatValue(rv.Index(i).Interface())
+			}
+			return strings.Join(items, ", ")
+		}
+	}
+
+	return fmt.Sprint(value)
+}
+
+// reference identifies a pointer or a map, so that values
+// that contain themselves can be detected.
+type reference struct {
+	typ reflect.Type
+	ptr uintptr
+}
+
+// referenceOf returns the reference of a pointer or a map, and
+// an invalid reference for other values.
+func referenceOf(rv reflect.Value) reference {
+	if (rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Map) && !rv.IsNil() {
+		return reference{rv.Type(), rv.Pointer()}
+	}
+	return reference{}
+}
+
+func (r reference) valid() bool {
+	return r.typ != nil
+}
+
+// nestedPairs returns the pairs of values that are rendered as nested
+// pairs, which are KeyValues, maps and structs, and pointers to them,
+// along with the reference of the pointer or map, if there is one.
+// Values that know how to format or marshal themselves, such as
+// time.Time, are not.
+func nestedPairs(value interface{}) ([]Pair, reference, bool) {
+	switch v := value.(type) {
+	case KeyValue:
+		return v.Pairs, v.ref, true
+	case *KeyValue:
+		if v == nil {
+			return nil, reference{}, false
+		}
+		return v.Pairs, referenceOf(reflect.ValueOf(v)), true
+	}
+
+	var ref reference
+	rv := reflect.ValueOf(value)
+	for rv.IsValid() && (rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface) {
+		if selfFormatted(rv) || rv.IsNil() {
+			return nil, reference{}, false
+		}
+		if !ref.valid() {
+			ref = referenceOf(rv)
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() || selfFormatted(rv) {
+		return nil, reference{}, false
+	}
+
+	switch rv.Kind() {
+	case reflect.Map:
+		return mapPairs(rv), referenceOf(rv), true
+	case reflect.Struct:
+		// A struct that embeds a pointer to itself
+		// should not promote its own fields again.
+		embeddedPtrs := make(map[reference]bool)
+		if ref.valid() {
+			embeddedPtrs[ref] = true
+		}
+		return structPairs(rv, embeddedPtrs), ref, true
+	}
+	return nil, reference{}, false
+}
+
+// selfFormatted returns whether a value formats or marshals itself.
+func selfFormatted(rv reflect.Value) bool {
+	if !rv.CanInterface() {
+		return false
+	}
+
+	switch rv.Interface().(type) {
+	case fmt.Stringer, error, json.Marshaler:
+		return true
+	}
+	return false
+}
+
+// mapPairs returns the entries of a map, sorted by key.
+func mapPairs(rv reflect.Value) []Pair {
+	pairs := make([]Pair, 0, rv.Len())
+	for _, key := range rv.MapKeys() {
+		pairs = append(pairs, Pair{
+			Key:   fmt.Sprint(key.Interface()),
+			Value: rv.MapIndex(key).Interface(),
+		})
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i].Key < pairs[j].Key
+	})
+	return pairs
+}
+
+// structPairs returns the exported fields of a struct, named after their
+// json tag if they have one. Like encoding/json, the fields of embedded
+// structs without a tag are promoted, except for those of embedded
+// pointers that were already promoted, which embed themselves.
+func structPairs(rv reflect.Value, embeddedPtrs map[reference]bool) []Pair {
+	var pairs []Pair
+	for i := 0; i < rv.NumField(); i++ {
+		field := rv.Type().Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name := strings.Split(tag, ",")[0]
+		if field.Anonymous && name == "" {
+			embedded := rv.Field(i)
+			if embedded.Kind() == reflect.Ptr && !embedded.IsNil() {
+				ref := referenceOf(embedded)
+				if embeddedPtrs[ref] {
+					continue
+				}
+				embeddedPtrs[ref] = true
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct && !selfFormatted(embedded) {
+				pairs = append(pairs, structPairs(embedded, embeddedPtrs)...)
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		pairs = append(pairs, Pair{
+			Key:   name,
+			Value: rv.Field(i).Interface(),
+		})
+	}
+	return pairs
+}
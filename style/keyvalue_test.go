@@ -0,0 +1,220 @@
+package style
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+)
+
+type kvMetadata struct {
+	Team string `json:"team"`
+}
+
+type kvResource struct {
+	kvMetadata
+	Name     string            `json:"name"`
+	Port     int               `json:"port"`
+	Tags     []string          `json:"tags"`
+	Labels   map[string]string `json:"labels"`
+	Created  time.Time         `json:"created"`
+	Password string            `json:"-"`
+	internal string
+}
+
+func TestKeyValue(t *testing.T) {
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	resource := kvResource{
+		kvMetadata: kvMetadata{Team: "infra"},
+		Name:       "db",
+		Port:       5432,
+		Tags:       []string{"sql", "primary"},
+		Labels:     map[string]string{"env": "prod", "app": "billing"},
+		Created:    created,
+		Password:   "secret",
+		internal:   "hidden",
+	}
+
+	testCases := []struct {
+		desc     string
+		kv       *KeyValue
+		expected string
+	}{
+		{
+			desc: "aligned values",
+			kv: NewKeyValue().
+				Add("name", "db").
+				Add("status", "running").
+				Add("port", 5432),
+			expected: "name:   db\n" +
+				"status: running\n" +
+				"port:   5432",
+		},
+		{
+			desc: "wrapped values with hanging indent",
+			kv: &KeyValue{
+				Pairs: []Pair{
+					{Key: "name", Value: "db"},
+					{Key: "description", Value: "primary database of the billing service"},
+				},
+				MaxWidth: 30,
+			},
+			expected: "name:        db\n" +
+				"description: primary database\n" +
+				"             of the billing\n" +
+				"             service",
+		},
+		{
+			desc: "nested key values",
+			kv: NewKeyValue().
+				Add("name", "db").
+				Add("limits", NewKeyValue().Add("cpu", "2").Add("memory", "4Gi")).
+				Add("empty", nil),
+			expected: "name:   db\n" +
+				"limits:\n" +
+				"  cpu:    2\n" +
+				"  memory: 4Gi\n" +
+				"empty:",
+		},
+		{
+			desc: "struct",
+			kv:   KeyValueOf(&resource),
+			expected: "team:    infra\n" +
+				"name:    db\n" +
+				"port:    5432\n" +
+				"tags:    sql, primary\n" +
+				"labels:\n" +
+				"  app: billing\n" +
+				"  env: prod\n" +
+				"created: " + created.String(),
+		},
+		{
+			desc: "json",
+			kv: &KeyValue{
+				Pairs:  KeyValueOf(resource).Pairs,
+				Format: KeyValueJSON,
+			},
+			expected: `{
+  "team": "infra",
+  "name": "db",
+  "port": 5432,
+  "tags": [
+    "sql",
+    "primary"
+  ],
+  "labels": {
+    "app": "billing",
+    "env": "prod"
+  },
+  "created": "2020-01-02T03:04:05Z"
+}`,
+		},
+		{
+			desc: "json strips styles and keeps order",
+			kv: &KeyValue{
+				Pairs: []Pair{
+					{Key: "status", Value: "\x1b[32mrunning\x1b[0m"},
+					{Key: "age", Value: 3},
+				},
+				Format: KeyValueJSON,
+			},
+			expected: "{\n  \"status\": \"running\",\n  \"age\": 3\n}",
+		},
+		{
+			desc:     "not a struct or map",
+			kv:       KeyValueOf(42),
+			expected: "",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.kv.String())
+		})
+	}
+}
+
+func TestKeyValueStyledKeys(t *testing.T) {
+	color.NoColor = false
+	defer func() {
+		color.NoColor = true
+	}()
+
+	kv := NewKeyValue().Add("id", 1).Add("name", "db")
+	assert.Equal(t, Important("id")+":   1\n"+Important("name")+": db", kv.String())
+}
+
+type kvNode struct {
+	Name   string  `json:"name"`
+	Parent *kvNode `json:"parent"`
+}
+
+type kvEmbedding struct {
+	*kvEmbedding
+	Name string `json:"name"`
+}
+
+func TestKeyValueCycles(t *testing.T) {
+	node := &kvNode{Name: "root"}
+	node.Parent = node
+
+	child := &kvNode{Name: "child", Parent: node}
+
+	loop := map[string]interface{}{"name": "loop"}
+	loop["self"] = loop
+
+	embedding := &kvEmbedding{Name: "embedding"}
+	embedding.kvEmbedding = embedding
+
+	testCases := []struct {
+		desc         string
+		kv           *KeyValue
+		expected     string
+		expectedJSON string
+	}{
+		{
+			desc:         "pointer to itself",
+			kv:           KeyValueOf(node),
+			expected:     "name:   root\nparent: <cycle>",
+			expectedJSON: "{\n  \"name\": \"root\",\n  \"parent\": \"<cycle>\"\n}",
+		},
+		{
+			desc: "pointer to an ancestor",
+			kv:   KeyValueOf(child),
+			expected: "name:   child\n" +
+				"parent:\n" +
+				"  name:   root\n" +
+				"  parent: <cycle>",
+			expectedJSON: "{\n  \"name\": \"child\",\n  \"parent\": {\n    \"name\": \"root\",\n    \"parent\": \"<cycle>\"\n  }\n}",
+		},
+		{
+			desc:         "map that contains itself",
+			kv:           KeyValueOf(loop),
+			expected:     "name: loop\nself: <cycle>",
+			expectedJSON: "{\n  \"name\": \"loop\",\n  \"self\": \"<cycle>\"\n}",
+		},
+		{
+			desc:         "struct that embeds itself",
+			kv:           KeyValueOf(embedding),
+			expected:     "name: embedding",
+			expectedJSON: "{\n  \"name\": \"embedding\"\n}",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.kv.String())
+
+			test.kv.Format = KeyValueJSON
+			assert.Equal(t, test.expectedJSON, test.kv.String())
+		})
+	}
+}
+
+func TestKeyValueJSONHyperlinks(t *testing.T) {
+	kv := NewKeyValue().Add("docs", "\x1b]8;;https://example.com\x1b\\\x1b[1mthe docs\x1b[0m\x1b]8;;\x1b\\")
+	kv.Format = KeyValueJSON
+
+	assert.Equal(t, "{\n  \"docs\": \"the docs (https://example.com)\"\n}", kv.String())
+}
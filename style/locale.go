@@ -0,0 +1,27 @@
+package style
+
+import (
+	"os"
+	"strings"
+)
+
+// SupportsUnicode returns whether or not the terminal supports UTF-8,
+// based on its locale, which is read from the LC_ALL, LC_CTYPE and LANG
+// environment variables, in that order of precedence. If none of them
+// is set, the terminal is assumed to support UTF-8, since most modern
+// terminals do.
+func SupportsUnicode() bool {
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		locale := os.Getenv(name)
+		if locale == "" {
+			continue
+		}
+
+		// Locales look like `en_US.UTF-8`, where the part after
+		// the dot is the character encoding.
+		locale = strings.ToLower(locale)
+		return strings.Contains(locale, "utf-8") || strings.Contains(locale, "utf8")
+	}
+
+	return true
+}
@@ -0,0 +1,90 @@
+package style
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setLocale sets the LANG environment variable, unsets the ones
+// that take precedence over it, and returns a function that
+// restores all of them.
+func setLocale(t *testing.T, lang string) func() {
+	saved := make(map[string]string)
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		saved[name] = os.Getenv(name)
+		require.NoError(t, os.Unsetenv(name))
+	}
+	require.NoError(t, os.Setenv("LANG", lang))
+
+	return func() {
+		for name, value := range saved {
+			os.Setenv(name, value)
+		}
+	}
+}
+
+func TestSupportsUnicode(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		env      map[string]string
+		expected bool
+	}{
+		{
+			desc:     "no locale",
+			expected: true,
+		},
+		{
+			desc:     "UTF-8 locale",
+			env:      map[string]string{"LANG": "en_US.UTF-8"},
+			expected: true,
+		},
+		{
+			desc:     "lower case UTF-8 locale",
+			env:      map[string]string{"LANG": "fr_FR.utf8"},
+			expected: true,
+		},
+		{
+			desc:     "C locale",
+			env:      map[string]string{"LANG": "C"},
+			expected: false,
+		},
+		{
+			desc:     "LC_ALL takes precedence",
+			env:      map[string]string{"LC_ALL": "POSIX", "LANG": "en_US.UTF-8"},
+			expected: false,
+		},
+		{
+			desc:     "LC_CTYPE takes precedence over LANG",
+			env:      map[string]string{"LC_CTYPE": "en_US.UTF-8", "LANG": "en_US.ISO-8859-1"},
+			expected: true,
+		},
+	}
+
+	saved := make(map[string]string)
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		saved[name] = os.Getenv(name)
+	}
+	defer func() {
+		for name, value := range saved {
+			os.Setenv(name, value)
+		}
+	}()
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			for name := range saved {
+				require.NoError(t, os.Setenv(name, test.env[name]))
+			}
+
+			assert.Equal(t, test.expected, SupportsUnicode())
+			if test.expected {
+				assert.Equal(t, DefaultSymbols, DetectSymbols())
+			} else {
+				assert.Equal(t, ASCIISymbols, DetectSymbols())
+			}
+		})
+	}
+}
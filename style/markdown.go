@@ -0,0 +1,385 @@
+package style
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// DefaultMarkdownWidth is the number of columns to which
+// Markdown is wrapped, unless specified otherwise.
+const DefaultMarkdownWidth = 80
+
+// MarkdownOptions configures how Markdown is rendered.
+type MarkdownOptions struct {
+	// Width is the number of columns to which paragraphs,
+	// headings, list items and quotes are wrapped. Code
+	// blocks are not wrapped.
+	Width int
+	// ASCII makes the rendering use ASCII characters for list
+	// bullets, quotes and rules, instead of UTF-8 ones.
+	ASCII bool
+	// Plain renders Markdown without any escape sequence, with
+	// headings underlined by characters and code kept between
+	// backticks, for outputs on which colors are disabled.
+	Plain bool
+	// Theme is the theme whose styles are used for links, quotes,
+	// rules and highlighted code blocks.
+	Theme Theme
+}
+
+// MarkdownWidth sets the number of columns to which Markdown is wrapped.
+func MarkdownWidth(width int) func(*MarkdownOptions) {
+	return func(options *MarkdownOptions) {
+		options.Width = width
+	}
+}
+
+// MarkdownASCII makes Markdown rendering use ASCII characters
+// for list bullets, quotes and rules, instead of UTF-8 ones.
+func MarkdownASCII(enabled bool) func(*MarkdownOptions) {
+	return func(options *MarkdownOptions) {
+		options.ASCII = enabled
+	}
+}
+
+// MarkdownPlain makes Markdown rendering use no escape sequences.
+func MarkdownPlain(enabled bool) func(*MarkdownOptions) {
+	return func(options *MarkdownOptions) {
+		options.Plain = enabled
+	}
+}
+
+// MarkdownTheme sets the theme whose styles are used to render Markdown.
+func MarkdownTheme(theme Theme) func(*MarkdownOptions) {
+	return func(options *MarkdownOptions) {
+		options.Theme = theme
+	}
+}
+
+var (
+	markdownHeading  = regexp.MustCompile(`^(#{1,6})\s+(.*?)(?:\s+#+)?\s*$`)
+	markdownRule     = regexp.MustCompile(`^(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+	markdownListItem = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+(.*)$`)
+	markdownFence    = regexp.MustCompile("^\\s*(```+|~~~+)\\s*([\\w+-]*)")
+
+	markdownCode     = regexp.MustCompile("`([^`]+)`")
+	markdownLink     = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)|<(https?://[^>\s]+)>`)
+	markdownBold     = regexp.MustCompile(`\*\*([^*\n]+)\*\*|__([^_\n]+)__`)
+	markdownItalic   = regexp.MustCompile(`\*([^*\n]+)\*`)
+	markdownItalicUS = regexp.MustCompile(`(^|[^\w])_([^_\n]+)_($|[^\w])`)
+)
+
+// markdownCodeStyle is the style of code spans and blocks.
+var markdownCodeStyle = Style{"cyan"}
+
+// Markdown renders a subset of Markdown, such as release notes or
+// help texts, as styled terminal output: headings, bold and italic
+// emphasis, inline code, fenced code blocks, lists, links, blockquotes
+// and horizontal rules. Links are rendered using Hyperlink, and code
+// blocks whose language is json or yaml are highlighted. By default,
+// Markdown is wrapped to DefaultMarkdownWidth columns, uses ASCII
+// characters if the terminal does not support UTF-8 according to
+// SupportsUnicode, is rendered without escape sequences when colors
+// are disabled, and uses the styles of DefaultTheme. The result has
+// no trailing newline.
+func Markdown(source string, options ...func(*MarkdownOptions)) string {
+	opts := MarkdownOptions{
+		Width: DefaultMarkdownWidth,
+		ASCII: !SupportsUnicode(),
+		Plain: color.NoColor,
+		Theme: DefaultTheme,
+	}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	source = strings.Replace(source, "\r\n", "\n", -1)
+	source = strings.Replace(source, "\t", "    ", -1)
+
+	r := markdownRenderer{opts}
+	return strings.Join(r.render(strings.Split(source, "\n"), opts.Width), "\n")
+}
+
+type markdownRenderer struct {
+	MarkdownOptions
+}
+
+// style applies a style to text, unless rendering is plain.
+func (r markdownRenderer) style(s Style, text string) string {
+	if r.Plain || text == "" {
+		return text
+	}
+	return s.Sprint(text)
+}
+
+// wrap wraps text to the given width, if it is positive.
+func (r markdownRenderer) wrap(text string, width int) []string {
+	if width > 0 && width < minMarkdownWidth {
+		width = minMarkdownWidth
+	}
+	return strings.Split(Wrap(text, width), "\n")
+}
+
+// minMarkdownWidth is the width under which
+// Markdown is not wrapped any further.
+const minMarkdownWidth = 10
+
+// render renders the blocks of a Markdown document,
+// separated by empty lines.
+func (r markdownRenderer) render(lines []string, width int) []string {
+	var rendered []string
+	add := func(block ...string) {
+		if len(rendered) > 0 {
+			rendered = append(rendered, "")
+		}
+		rendered = append(rendered, block...)
+	}
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			i++
+		case markdownFence.MatchString(line):
+			match := markdownFence.FindStringSubmatch(line)
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), match[1]); i++ {
+				code = append(code, lines[i])
+			}
+			i++
+			add(r.code(code, strings.ToLower(match[2]))...)
+		case markdownHeading.MatchString(trimmed):
+			match := markdownHeading.FindStringSubmatch(trimmed)
+			add(r.heading(len(match[1]), match[2], width)...)
+			i++
+		case markdownRule.MatchString(trimmed):
+			add(r.rule(width))
+			i++
+		case strings.HasPrefix(trimmed, ">"):
+			var quoted []string
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">"); i++ {
+				content := strings.TrimPrefix(strings.TrimSpace(lines[i]), ">")
+				quoted = append(quoted, strings.TrimPrefix(content, " "))
+			}
+			add(r.quote(quoted, width)...)
+		case markdownListItem.MatchString(line):
+			var items []string
+			for ; i < len(lines) && strings.TrimSpace(lines[i]) != ""; i++ {
+				if markdownListItem.MatchString(lines[i]) {
+					items = append(items, lines[i])
+					continue
+				}
+				if r.startsBlock(lines[i]) {
+					break
+				}
+				// Lines that don't start an item continue the previous one.
+				items[len(items)-1] += " " + strings.TrimSpace(lines[i])
+			}
+			add(r.list(items, width)...)
+		default:
+			var paragraph []string
+			for ; i < len(lines) && strings.TrimSpace(lines[i]) != "" && (len(paragraph) == 0 || !r.startsBlock(lines[i])); i++ {
+				paragraph = append(paragraph, strings.TrimSpace(lines[i]))
+			}
+			add(r.wrap(r.inline(strings.Join(paragraph, " ")), width)...)
+		}
+	}
+
+	return rendered
+}
+
+// startsBlock returns whether a line starts a block
+// other than a paragraph.
+func (r markdownRenderer) startsBlock(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return markdownFence.MatchString(line) ||
+		markdownHeading.MatchString(trimmed) ||
+		markdownRule.MatchString(trimmed) ||
+		strings.HasPrefix(trimmed, ">") ||
+		markdownListItem.MatchString(line)
+}
+
+// heading renders a heading. Top-level headings are underlined, and
+// other headings are bold. In plain rendering, the first two levels are
+// underlined with characters, and the others keep their markers.
+func (r markdownRenderer) heading(level int, text string, width int) []string {
+	text = r.inline(text)
+
+	if r.Plain {
+		lines := r.wrap(text, width)
+		switch level {
+		case 1, 2:
+			underline := "="
+			if level == 2 {
+				underline = "-"
+			}
+
+			longest := 0
+			for _, line := range lines {
+				if w := Width(line); w > longest {
+					longest = w
+				}
+			}
+			return append(lines, strings.Repeat(underline, longest))
+		}
+		return r.wrap(strings.Repeat("#", level)+" "+text, width)
+	}
+
+	headingStyle := Style{"bold"}
+	if level == 1 {
+		headingStyle = Style{"bold", "underline"}
+	}
+	return r.wrap(headingStyle.Sprint(text), width)
+}
+
+// rule renders a horizontal rule that spans the width.
+func (r markdownRenderer) rule(width int) string {
+	if width <= 0 {
+		width = DefaultMarkdownWidth
+	}
+
+	character := "─"
+	if r.ASCII {
+		character = "-"
+	}
+	return r.style(r.Theme.Trace, strings.Repeat(character, width))
+}
+
+// quote renders the content of a blockquote,
+// prefixed with a vertical bar.
+func (r markdownRenderer) quote(lines []string, width int) []string {
+	bar := "│ "
+	if r.ASCII {
+		bar = "| "
+	}
+	bar = r.style(r.Theme.Trace, bar)
+
+	rendered := r.render(lines, width-2)
+	for i, line := range rendered {
+		rendered[i] = strings.TrimRight(bar+line, " ")
+	}
+	return rendered
+}
+
+// list renders list items, which are indented according to their
+// nesting, and whose continuation lines are aligned with their text.
+func (r markdownRenderer) list(items []string, width int) []string {
+	bullet := "•"
+	if r.ASCII {
+		bullet = "-"
+	}
+
+	var rendered []string
+	for _, item := range items {
+		match := markdownListItem.FindStringSubmatch(item)
+		indent := strings.Repeat("  ", len(match[1])/2)
+
+		marker := bullet
+		if _, err := strconv.Atoi(strings.TrimRight(match[2], ".)")); err == nil {
+			marker = match[2]
+		}
+		prefix := indent + marker + " "
+
+		hanging := strings.Repeat(" ", Width(prefix))
+		for i, line := range r.wrap(r.inline(match[3]), width-Width(prefix)) {
+			if i == 0 {
+				rendered = append(rendered, prefix+line)
+			} else {
+				rendered = append(rendered, hanging+line)
+			}
+		}
+	}
+	return rendered
+}
+
+// code renders the lines of a code block, indented, and highlighted
+// if their language is JSON or YAML.
+func (r markdownRenderer) code(lines []string, language string) []string {
+	content := strings.Join(lines, "\n")
+	if !r.Plain {
+		switch language {
+		case "json":
+			content = r.Theme.Syntax.highlightJSON(content)
+		case "yaml", "yml":
+			content = r.Theme.Syntax.highlightYAML(content)
+		default:
+			content = strings.Join(carryStyles(strings.Split(markdownCodeStyle.Sprint(content), "\n")), "\n")
+		}
+	}
+
+	rendered := strings.Split(content, "\n")
+	for i, line := range rendered {
+		rendered[i] = strings.TrimRight("    "+line, " ")
+	}
+	return rendered
+}
+
+// inline renders the inline elements of text: code spans,
+// links, and bold and italic emphasis.
+func (r markdownRenderer) inline(text string) string {
+	var result strings.Builder
+
+	last := 0
+	for _, match := range markdownCode.FindAllStringSubmatchIndex(text, -1) {
+		result.WriteString(r.links(text[last:match[0]]))
+
+		code := text[match[2]:match[3]]
+		if r.Plain {
+			result.WriteString("`" + code + "`")
+		} else {
+			result.WriteString(markdownCodeStyle.Sprint(code))
+		}
+		last = match[1]
+	}
+	result.WriteString(r.links(text[last:]))
+
+	return result.String()
+}
+
+// links renders the links of text, and the emphasis around them.
+func (r markdownRenderer) links(text string) string {
+	var result strings.Builder
+
+	last := 0
+	for _, match := range markdownLink.FindAllStringSubmatchIndex(text, -1) {
+		result.WriteString(r.emphasis(text[last:match[0]]))
+
+		var url, label string
+		if match[2] >= 0 {
+			label, url = r.emphasis(text[match[2]:match[3]]), text[match[4]:match[5]]
+		} else {
+			url = text[match[6]:match[7]]
+			label = url
+		}
+
+		if r.Plain {
+			result.WriteString(hyperlinkFallback(url, label))
+		} else {
+			result.WriteString(Hyperlink(url, r.Theme.Link.Sprint(label)))
+		}
+		last = match[1]
+	}
+	result.WriteString(r.emphasis(text[last:]))
+
+	return result.String()
+}
+
+// emphasis renders bold and italic emphasis.
+func (r markdownRenderer) emphasis(text string) string {
+	text = markdownBold.ReplaceAllStringFunc(text, func(s string) string {
+		match := markdownBold.FindStringSubmatch(s)
+		return r.style(Style{"bold"}, match[1]+match[2])
+	})
+	text = markdownItalic.ReplaceAllStringFunc(text, func(s string) string {
+		return r.style(Style{"italic"}, markdownItalic.FindStringSubmatch(s)[1])
+	})
+	return markdownItalicUS.ReplaceAllStringFunc(text, func(s string) string {
+		match := markdownItalicUS.FindStringSubmatch(s)
+		return match[1] + r.style(Style{"italic"}, match[2]) + match[3]
+	})
+}
@@ -0,0 +1,121 @@
+package style
+
+import (
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+)
+
+const releaseNotes = `# Release notes
+
+Version **2.0** brings _faster_ installs and a new ` + "`--dry-run`" + ` flag,
+see [the docs](https://example.com/docs) for details.
+
+## Breaking changes
+
+- The *config* file moved.
+- Plugins must be rebuilt against the new API, which is not compatible with the previous one.
+  1. Update your dependencies.
+  2. Rebuild.
+
+> Thanks to all contributors!
+
+` + "```" + `
+disgo --dry-run
+` + "```" + `
+
+---`
+
+func TestMarkdownPlain(t *testing.T) {
+	rendered := Markdown(releaseNotes, MarkdownPlain(true), MarkdownASCII(true), MarkdownWidth(40))
+
+	assert.Equal(t, "Release notes\n"+
+		"=============\n"+
+		"\n"+
+		"Version 2.0 brings faster installs and a\n"+
+		"new `--dry-run` flag, see the docs\n"+
+		"(https://example.com/docs) for details.\n"+
+		"\n"+
+		"Breaking changes\n"+
+		"----------------\n"+
+		"\n"+
+		"- The config file moved.\n"+
+		"- Plugins must be rebuilt against the\n"+
+		"  new API, which is not compatible with\n"+
+		"  the previous one.\n"+
+		"  1. Update your dependencies.\n"+
+		"  2. Rebuild.\n"+
+		"\n"+
+		"| Thanks to all contributors!\n"+
+		"\n"+
+		"    disgo --dry-run\n"+
+		"\n"+
+		"----------------------------------------", rendered)
+}
+
+func TestMarkdownStyled(t *testing.T) {
+	color.NoColor = false
+	defer func(enabled bool) {
+		color.NoColor = true
+		Hyperlinks = enabled
+	}(Hyperlinks)
+	Hyperlinks = true
+
+	testCases := []struct {
+		desc     string
+		source   string
+		expected string
+	}{
+		{
+			desc:     "headings",
+			source:   "# Title\n\n### Details ###",
+			expected: "\x1b[1;4mTitle\x1b[0m\n\n\x1b[1mDetails\x1b[0m",
+		},
+		{
+			desc:     "emphasis and code",
+			source:   "**bold**, *italic*, snake_case and `code`",
+			expected: "\x1b[1mbold\x1b[0m, \x1b[3mitalic\x1b[0m, snake_case and \x1b[36mcode\x1b[0m",
+		},
+		{
+			desc:     "links",
+			source:   "[docs](https://example.com) <https://example.org>",
+			expected: "\x1b]8;;https://example.com\x1b\\\x1b[34;4mdocs\x1b[0m\x1b]8;;\x1b\\ \x1b]8;;https://example.org\x1b\\\x1b[34;4mhttps://example.org\x1b[0m\x1b]8;;\x1b\\",
+		},
+		{
+			desc:     "unicode list and quote",
+			source:   "* one\n+ two\n\n> quoted",
+			expected: "• one\n• two\n\n\x1b[97;2m│ \x1b[0mquoted",
+		},
+		{
+			desc:     "json code block",
+			source:   "```json\n{\"a\": 1}\n```",
+			expected: "    {\x1b[36m\"a\"\x1b[0m: \x1b[33m1\x1b[0m}",
+		},
+		{
+			desc:     "code block",
+			source:   "```sh\nmake\nmake install\n```",
+			expected: "    \x1b[36mmake\x1b[0m\n    \x1b[36mmake install\x1b[0m",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, Markdown(test.source, MarkdownASCII(false)))
+		})
+	}
+}
+
+func TestMarkdownTheme(t *testing.T) {
+	color.NoColor = false
+	defer func() {
+		color.NoColor = true
+	}()
+
+	theme := DefaultTheme
+	theme.Trace = Style{"yellow"}
+	theme.Link = Style{"bold"}
+
+	assert.Equal(t, "\x1b[33m| \x1b[0m\x1b[1mdocs\x1b[0m (https://example.com)",
+		Markdown("> [docs](https://example.com)", MarkdownASCII(true), MarkdownTheme(theme)))
+}
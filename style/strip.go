@@ -0,0 +1,15 @@
+package style
+
+import "regexp"
+
+// ansiPattern matches ANSI escape sequences, such as the SGR
+// sequences that are used to color outputs, cursor movements,
+// and operating system commands such as hyperlinks.
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)`)
+
+// Strip removes all ANSI escape sequences from a message, which
+// makes it suitable to be written on outputs that do not support
+// them, such as files.
+func Strip(message string) string {
+	return ansiPattern.ReplaceAllString(message, "")
+}
@@ -0,0 +1,42 @@
+package style
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrip(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		message  string
+		expected string
+	}{
+		{
+			desc:     "plain message",
+			message:  "nothing to strip",
+			expected: "nothing to strip",
+		},
+		{
+			desc:     "colored message",
+			message:  "\x1b[32;1mok\x1b[0m",
+			expected: "ok",
+		},
+		{
+			desc:     "cursor movement",
+			message:  "\x1b[2K\x1b[1Gprogress",
+			expected: "progress",
+		},
+		{
+			desc:     "hyperlink",
+			message:  "\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\",
+			expected: "link",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, Strip(test.message))
+		})
+	}
+}
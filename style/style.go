@@ -0,0 +1,145 @@
+package style
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// attributes maps the names of the attributes that styles can
+// be made of to their color attribute.
+var attributes = map[string]color.Attribute{
+	"bold":        color.Bold,
+	"faint":       color.Faint,
+	"italic":      color.Italic,
+	"underline":   color.Underline,
+	"blink":       color.BlinkSlow,
+	"reverse":     color.ReverseVideo,
+	"crossed-out": color.CrossedOut,
+
+	"black":   color.FgBlack,
+	"red":     color.FgRed,
+	"green":   color.FgGreen,
+	"yellow":  color.FgYellow,
+	"blue":    color.FgBlue,
+	"magenta": color.FgMagenta,
+	"cyan":    color.FgCyan,
+	"white":   color.FgWhite,
+
+	"hi-black":   color.FgHiBlack,
+	"hi-red":     color.FgHiRed,
+	"hi-green":   color.FgHiGreen,
+	"hi-yellow":  color.FgHiYellow,
+	"hi-blue":    color.FgHiBlue,
+	"hi-magenta": color.FgHiMagenta,
+	"hi-cyan":    color.FgHiCyan,
+	"hi-white":   color.FgHiWhite,
+
+	"bg-black":   color.BgBlack,
+	"bg-red":     color.BgRed,
+	"bg-green":   color.BgGreen,
+	"bg-yellow":  color.BgYellow,
+	"bg-blue":    color.BgBlue,
+	"bg-magenta": color.BgMagenta,
+	"bg-cyan":    color.BgCyan,
+	"bg-white":   color.BgWhite,
+
+	"bg-hi-black":   color.BgHiBlack,
+	"bg-hi-red":     color.BgHiRed,
+	"bg-hi-green":   color.BgHiGreen,
+	"bg-hi-yellow":  color.BgHiYellow,
+	"bg-hi-blue":    color.BgHiBlue,
+	"bg-hi-magenta": color.BgHiMagenta,
+	"bg-hi-cyan":    color.BgHiCyan,
+	"bg-hi-white":   color.BgHiWhite,
+}
+
+// Style is a list of attributes that are applied to messages, such
+// as `bold`, `underline`, a color like `green` or `hi-green`, or a
+// background color like `bg-green`. Colors can also be given in the
+// `#rrggbb` hexadecimal notation, or as `color-N` where N is the index
+// of a color in the palette of 256 colors, and background colors as
+// `bg-#rrggbb` or `bg-color-N`. Those are downsampled according to
+// Profile. An empty style leaves messages as they are.
+type Style []string
+
+// params returns the SGR parameters of an attribute,
+// rendered with the given profile.
+func params(name string, profile ColorProfile) ([]int, error) {
+	original := name
+	name = strings.ToLower(name)
+	if attr, ok := attributes[name]; ok {
+		return []int{int(attr)}, nil
+	}
+
+	background := strings.HasPrefix(name, "bg-")
+	name = strings.TrimPrefix(name, "bg-")
+
+	switch {
+	case strings.HasPrefix(name, "#"):
+		c, err := parseHex(name)
+		if err != nil {
+			return nil, err
+		}
+		return rgbParams(c, background, profile), nil
+	case strings.HasPrefix(name, "color-"):
+		index, err := strconv.Atoi(strings.TrimPrefix(name, "color-"))
+		if err != nil || index < 0 || index > 255 {
+			return nil, fmt.Errorf("invalid 256 color %q", original)
+		}
+		return color256Params(index, background, profile), nil
+	}
+
+	return nil, fmt.Errorf("unknown style attribute %q", original)
+}
+
+// Validate returns an error if the style contains unknown attributes.
+func (s Style) Validate() error {
+	for _, name := range s {
+		if _, err := params(name, TrueColor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Sprint formats its arguments like fmt.Sprint, and applies the style
+// to the result. Like the package's formatting helpers, it does not
+// apply anything when colors are disabled. Unknown attributes are
+// ignored.
+func (s Style) Sprint(a ...interface{}) string {
+	message := fmt.Sprint(a...)
+	if color.NoColor {
+		return message
+	}
+	return s.apply(message)
+}
+
+// Render formats its arguments like fmt.Sprint, and applies the style to
+// the result even if colors are disabled package-wide. It is meant for
+// callers that decide on their own whether or not colors are enabled,
+// such as terminals, whose writers can each have their own policy.
+func (s Style) Render(a ...interface{}) string {
+	return s.apply(fmt.Sprint(a...))
+}
+
+// apply wraps a message in the escape sequences of the style.
+func (s Style) apply(message string) string {
+	var sgr []string
+	for _, name := range s {
+		attrParams, err := params(name, Profile)
+		if err != nil {
+			continue
+		}
+		for _, param := range attrParams {
+			sgr = append(sgr, strconv.Itoa(param))
+		}
+	}
+	if len(sgr) == 0 {
+		return message
+	}
+
+	return "\x1b[" + strings.Join(sgr, ";") + "m" + message + "\x1b[0m"
+}
@@ -0,0 +1,120 @@
+package style
+
+import (
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStyleSprint(t *testing.T) {
+	color.NoColor = false
+	defer func() {
+		color.NoColor = true
+	}()
+
+	profile := Profile
+	defer func() {
+		Profile = profile
+	}()
+
+	testCases := []struct {
+		desc     string
+		style    Style
+		profile  ColorProfile
+		expected string
+	}{
+		{
+			desc:     "no attributes",
+			style:    Style{},
+			expected: "message 42",
+		},
+		{
+			desc:     "color and weight",
+			style:    Style{"green", "bold"},
+			expected: "\x1b[32;1mmessage 42\x1b[0m",
+		},
+		{
+			desc:     "case insensitive",
+			style:    Style{"BG-Hi-Red"},
+			expected: "\x1b[101mmessage 42\x1b[0m",
+		},
+		{
+			desc:     "unknown attributes are ignored",
+			style:    Style{"sparkly", "underline"},
+			expected: "\x1b[4mmessage 42\x1b[0m",
+		},
+		{
+			desc:     "truecolor",
+			style:    append(RGB("#ff8800"), "bold"),
+			profile:  TrueColor,
+			expected: "\x1b[38;2;255;136;0;1mmessage 42\x1b[0m",
+		},
+		{
+			desc:     "short hexadecimal notation",
+			style:    BgRGB("#f80"),
+			profile:  TrueColor,
+			expected: "\x1b[48;2;255;136;0mmessage 42\x1b[0m",
+		},
+		{
+			desc:     "truecolor downsampled to 256 colors",
+			style:    RGB("#ff8800"),
+			profile:  ANSI256,
+			expected: "\x1b[38;5;208mmessage 42\x1b[0m",
+		},
+		{
+			desc:     "gray downsampled to 256 colors",
+			style:    BgRGB("#808080"),
+			profile:  ANSI256,
+			expected: "\x1b[48;5;244mmessage 42\x1b[0m",
+		},
+		{
+			desc:     "truecolor downsampled to 16 colors",
+			style:    RGB("#ff8800"),
+			profile:  ANSI,
+			expected: "\x1b[33mmessage 42\x1b[0m",
+		},
+		{
+			desc:     "256 colors",
+			style:    Color256(208),
+			profile:  TrueColor,
+			expected: "\x1b[38;5;208mmessage 42\x1b[0m",
+		},
+		{
+			desc:     "256 colors downsampled to 16 colors",
+			style:    BgColor256(22),
+			profile:  ANSI,
+			expected: "\x1b[40mmessage 42\x1b[0m",
+		},
+		{
+			desc:     "basic 256 colors downsampled to 16 colors",
+			style:    Color256(9),
+			profile:  ANSI,
+			expected: "\x1b[91mmessage 42\x1b[0m",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			Profile = test.profile
+			assert.Equal(t, test.expected, test.style.Sprint("message ", 42))
+		})
+	}
+}
+
+func TestStyleSprintNoColor(t *testing.T) {
+	assert.Equal(t, "message", Style{"green", "bold"}.Sprint("message"))
+}
+
+func TestStyleRender(t *testing.T) {
+	// Styles are rendered even though colors are disabled package-wide.
+	assert.Equal(t, "\x1b[32;1mmessage 42\x1b[0m", Style{"green", "bold"}.Render("message ", 42))
+	assert.Equal(t, "message", Style{}.Render("message"))
+}
+
+func TestStyleValidate(t *testing.T) {
+	assert.NoError(t, Style{"green", "bold", "#ff8800", "bg-#FFF", "color-208", "bg-color-0"}.Validate())
+	assert.EqualError(t, Style{"green", "sparkly"}.Validate(), `unknown style attribute "sparkly"`)
+	assert.EqualError(t, Style{"#ff88zz"}.Validate(), `invalid hexadecimal color "#ff88zz"`)
+	assert.EqualError(t, Style{"bg-color-256"}.Validate(), `invalid 256 color "bg-color-256"`)
+}
@@ -0,0 +1,308 @@
+package style
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strings"
+)
+
+// Alignment represents how the content of a table
+// column is aligned.
+type Alignment int
+
+const (
+	// AlignLeft aligns content on the left.
+	AlignLeft Alignment = iota
+	// AlignRight aligns content on the right, which
+	// is useful for numbers.
+	AlignRight
+	// AlignCenter centers content.
+	AlignCenter
+)
+
+// TableFormat represents the format in which a table is rendered.
+type TableFormat int
+
+const (
+	// TableText renders tables with aligned columns, for humans.
+	TableText TableFormat = iota
+	// TableTSV renders tables as tab-separated values, without
+	// styles, which is convenient for non-TTY outputs that are
+	// processed by other programs.
+	TableTSV
+	// TableCSV renders tables as comma-separated values,
+	// without styles.
+	TableCSV
+)
+
+// Characters used to draw the borders of tables.
+type tableBorders struct {
+	horizontal, vertical                  string
+	topLeft, topMiddle, topRight          string
+	middleLeft, middleMiddle, middleRight string
+	bottomLeft, bottomMiddle, bottomRight string
+}
+
+var (
+	unicodeBorders = tableBorders{
+		"─", "│",
+		"┌", "┬", "┐",
+		"├", "┼", "┤",
+		"└", "┴", "┘",
+	}
+	asciiBorders = tableBorders{
+		"-", "|",
+		"+", "+", "+",
+		"+", "+", "+",
+		"+", "+", "+",
+	}
+)
+
+// Table renders rows of cells in aligned columns. Cell widths are
+// computed based on how cells are displayed, so that styled cells and
+// wide characters are aligned properly. Since it implements
+// fmt.Stringer, a table can be printed through a Terminal, using
+// Infoln for example, so that it is queued during steps.
+type Table struct {
+	// Headers of the columns. They are rendered in bold.
+	Headers []string
+	// Rows of cells.
+	Rows [][]string
+
+	// Alignments of the columns. Columns are aligned on
+	// the left if their alignment is not specified.
+	Alignments []Alignment
+	// MaxWidth is the maximum number of columns that the table can
+	// take, which is usually the width of the terminal. The widest
+	// columns are shrunk, and their cells truncated with an ellipsis,
+	// so that the table fits. If it is 0, the table is not limited.
+	MaxWidth int
+	// Borders enables borders around the table and its cells.
+	Borders bool
+	// ASCII makes the table use ASCII characters for its
+	// borders and ellipses, instead of UTF-8 ones. Tables created
+	// with NewTable use them if the terminal does not support UTF-8
+	// according to SupportsUnicode.
+	ASCII bool
+	// Format in which the table is rendered.
+	Format TableFormat
+}
+
+// NewTable creates a table with the given headers, which uses ASCII
+// characters if the terminal does not support UTF-8.
+func NewTable(headers ...string) *Table {
+	return &Table{
+		Headers: headers,
+		ASCII:   !SupportsUnicode(),
+	}
+}
+
+// AddRow adds a row to the table, whose cells are formatted
+// like fmt.Sprint would format them.
+func (t *Table) AddRow(cells ...interface{}) *Table {
+	row := make([]string, len(cells))
+	for i, cell := range cells {
+		row[i] = fmt.Sprint(cell)
+	}
+
+	t.Rows = append(t.Rows, row)
+	return t
+}
+
+// String renders the table, without a trailing newline.
+func (t Table) String() string {
+	switch t.Format {
+	case TableTSV:
+		return t.tsv()
+	case TableCSV:
+		return t.csv()
+	}
+	return t.text()
+}
+
+// columns returns the number of columns of the table.
+func (t Table) columns() int {
+	columns := len(t.Headers)
+	for _, row := range t.Rows {
+		if len(row) > columns {
+			columns = len(row)
+		}
+	}
+	return columns
+}
+
+// records returns the headers, if there are any, and rows of the
+// table, all with the same number of cells, and with newlines
+// replaced with spaces.
+func (t Table) records() [][]string {
+	columns := t.columns()
+
+	var records [][]string
+	if len(t.Headers) > 0 {
+		records = append(records, t.Headers)
+	}
+	records = append(records, t.Rows...)
+
+	normalized := make([][]string, len(records))
+	for i, record := range records {
+		normalized[i] = make([]string, columns)
+		for j, cell := range record {
+			normalized[i][j] = strings.Replace(strings.Replace(cell, "\r", "", -1), "\n", " ", -1)
+		}
+	}
+
+	return normalized
+}
+
+func (t Table) tsv() string {
+	var lines []string
+	for _, record := range t.records() {
+		cells := make([]string, len(record))
+		for i, cell := range record {
+			cells[i] = strings.Replace(Strip(StripHyperlinks(cell)), "\t", " ", -1)
+		}
+		lines = append(lines, strings.Join(cells, "\t"))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (t Table) csv() string {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	for _, record := range t.records() {
+		cells := make([]string, len(record))
+		for i, cell := range record {
+			cells[i] = Strip(StripHyperlinks(cell))
+		}
+		w.Write(cells)
+	}
+	w.Flush()
+
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func (t Table) text() string {
+	records := t.records()
+	if len(records) == 0 {
+		return ""
+	}
+
+	widths := make([]int, t.columns())
+	for _, record := range records {
+		for i, cell := range record {
+			if w := Width(cell); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+	t.fit(widths)
+
+	borders := unicodeBorders
+	ellipsis := Ellipsis
+	if t.ASCII {
+		borders = asciiBorders
+		ellipsis = "..."
+	}
+
+	var lines []string
+	if t.Borders {
+		lines = append(lines, t.separator(widths, borders.topLeft, borders.topMiddle, borders.topRight, borders.horizontal))
+	}
+
+	for i, record := range records {
+		cells := make([]string, len(record))
+		for j, cell := range record {
+			cell = Truncate(cell, widths[j], ellipsis)
+			if i == 0 && len(t.Headers) > 0 {
+				cell = Important(cell)
+			}
+			cells[j] = t.align(cell, widths[j], j)
+		}
+
+		if t.Borders {
+			lines = append(lines, borders.vertical+" "+strings.Join(cells, " "+borders.vertical+" ")+" "+borders.vertical)
+		} else {
+			lines = append(lines, strings.TrimRight(strings.Join(cells, "  "), " "))
+		}
+
+		if t.Borders && i == 0 && len(t.Headers) > 0 && len(records) > 1 {
+			lines = append(lines, t.separator(widths, borders.middleLeft, borders.middleMiddle, borders.middleRight, borders.horizontal))
+		}
+	}
+
+	if t.Borders {
+		lines = append(lines, t.separator(widths, borders.bottomLeft, borders.bottomMiddle, borders.bottomRight, borders.horizontal))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// minColumnWidth is the width under which
+// columns are not shrunk to fit the table.
+const minColumnWidth = 3
+
+// fit shrinks the widest columns until the table
+// fits in its maximum width, if it has one.
+func (t Table) fit(widths []int) {
+	if t.MaxWidth <= 0 {
+		return
+	}
+
+	// Columns are separated by two spaces, or by a
+	// border and a space on each side of it.
+	total := 2 * (len(widths) - 1)
+	if t.Borders {
+		total = 3*len(widths) + 1
+	}
+	for _, w := range widths {
+		total += w
+	}
+
+	for total > t.MaxWidth {
+		widest := 0
+		for i, w := range widths {
+			if w > widths[widest] {
+				widest = i
+			}
+		}
+		if widths[widest] <= minColumnWidth {
+			return
+		}
+
+		widths[widest]--
+		total--
+	}
+}
+
+// align pads a cell so that it takes the given width,
+// according to the alignment of its column.
+func (t Table) align(cell string, width, column int) string {
+	alignment := AlignLeft
+	if column < len(t.Alignments) {
+		alignment = t.Alignments[column]
+	}
+
+	padding := width - Width(cell)
+	if padding <= 0 {
+		return cell
+	}
+
+	switch alignment {
+	case AlignRight:
+		return strings.Repeat(" ", padding) + cell
+	case AlignCenter:
+		return strings.Repeat(" ", padding/2) + cell + strings.Repeat(" ", padding-padding/2)
+	}
+	return pad(cell, width)
+}
+
+// separator returns a horizontal border line.
+func (t Table) separator(widths []int, left, middle, right, horizontal string) string {
+	parts := make([]string, len(widths))
+	for i, w := range widths {
+		parts[i] = strings.Repeat(horizontal, w+2)
+	}
+	return left + strings.Join(parts, middle) + right
+}
@@ -0,0 +1,140 @@
+package style
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTable(t *testing.T) {
+	defer setLocale(t, "en_US.UTF-8")()
+
+	newTable := func() *Table {
+		return NewTable("NAME", "STATUS", "PORTS").
+			AddRow("db", Success("running"), 5432).
+			AddRow("日本語", Failure("stopped"), 80).
+			AddRow("cache")
+	}
+
+	testCases := []struct {
+		desc     string
+		table    func() *Table
+		expected string
+	}{
+		{
+			desc:  "aligned columns",
+			table: newTable,
+			expected: "NAME    STATUS   PORTS\n" +
+				"db      running  5432\n" +
+				"日本語  stopped  80\n" +
+				"cache",
+		},
+		{
+			desc: "alignments",
+			table: func() *Table {
+				table := newTable()
+				table.Alignments = []Alignment{AlignCenter, AlignLeft, AlignRight}
+				return table
+			},
+			expected: " NAME   STATUS   PORTS\n" +
+				"  db    running   5432\n" +
+				"日本語  stopped     80\n" +
+				"cache",
+		},
+		{
+			desc: "borders",
+			table: func() *Table {
+				table := newTable()
+				table.Borders = true
+				return table
+			},
+			expected: "┌────────┬─────────┬───────┐\n" +
+				"│ NAME   │ STATUS  │ PORTS │\n" +
+				"├────────┼─────────┼───────┤\n" +
+				"│ db     │ running │ 5432  │\n" +
+				"│ 日本語 │ stopped │ 80    │\n" +
+				"│ cache  │         │       │\n" +
+				"└────────┴─────────┴───────┘",
+		},
+		{
+			desc: "ASCII borders",
+			table: func() *Table {
+				table := NewTable("A", "B").AddRow(1, 2)
+				table.Borders = true
+				table.ASCII = true
+				return table
+			},
+			expected: "+---+---+\n" +
+				"| A | B |\n" +
+				"+---+---+\n" +
+				"| 1 | 2 |\n" +
+				"+---+---+",
+		},
+		{
+			desc: "truncated to fit",
+			table: func() *Table {
+				table := NewTable("NAME", "DESCRIPTION").
+					AddRow("db", "The main database of the application").
+					AddRow("cache", "In-memory cache")
+				table.MaxWidth = 30
+				return table
+			},
+			expected: "NAME   DESCRIPTION\n" +
+				"db     The main database of t…\n" +
+				"cache  In-memory cache",
+		},
+		{
+			desc: "TSV",
+			table: func() *Table {
+				table := NewTable("NAME", "DESCRIPTION").AddRow(Success("db"), "multi\nline\twith tab")
+				table.Format = TableTSV
+				return table
+			},
+			expected: "NAME\tDESCRIPTION\n" +
+				"db\tmulti line with tab",
+		},
+		{
+			desc: "CSV",
+			table: func() *Table {
+				table := NewTable("NAME", "DESCRIPTION").AddRow(Success("db"), `quoted "value", with comma`)
+				table.Format = TableCSV
+				return table
+			},
+			expected: "NAME,DESCRIPTION\n" +
+				`db,"quoted ""value"", with comma"`,
+		},
+		{
+			desc: "no headers",
+			table: func() *Table {
+				return (&Table{}).AddRow("a", "b")
+			},
+			expected: "a  b",
+		},
+		{
+			desc: "empty",
+			table: func() *Table {
+				return &Table{}
+			},
+			expected: "",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.table().String())
+		})
+	}
+}
+
+func TestTableLocale(t *testing.T) {
+	defer setLocale(t, "C")()
+
+	table := NewTable("A", "B").AddRow(1, 2)
+	table.Borders = true
+
+	assert.Equal(t, "+---+---+\n"+
+		"| A | B |\n"+
+		"+---+---+\n"+
+		"| 1 | 2 |\n"+
+		"+---+---+", table.String())
+}
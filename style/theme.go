@@ -0,0 +1,263 @@
+package style
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Symbols is a set of symbols used to build command-line interfaces.
+type Symbols struct {
+	Check         string `json:"check" yaml:"check"`
+	Cross         string `json:"cross" yaml:"cross"`
+	LeftArrow     string `json:"left_arrow" yaml:"left_arrow"`
+	RightArrow    string `json:"right_arrow" yaml:"right_arrow"`
+	LeftTriangle  string `json:"left_triangle" yaml:"left_triangle"`
+	RightTriangle string `json:"right_triangle" yaml:"right_triangle"`
+}
+
+// DefaultSymbols are the package's UTF-8 symbols.
+var DefaultSymbols = Symbols{
+	Check:         SymbolCheck,
+	Cross:         SymbolCross,
+	LeftArrow:     SymbolLeftArrow,
+	RightArrow:    SymbolRightArrow,
+	LeftTriangle:  SymbolLeftTriangle,
+	RightTriangle: SymbolRightTriangle,
+}
+
+// ASCIISymbols are ASCII replacements for the package's UTF-8
+// symbols, for terminals that can't display UTF-8.
+var ASCIISymbols = Symbols{
+	Check:         "v",
+	Cross:         "[x]",
+	LeftArrow:     "<",
+	RightArrow:    ">",
+	LeftTriangle:  "<|",
+	RightTriangle: "|>",
+}
+
+// DetectSymbols returns DefaultSymbols if the terminal supports
+// UTF-8 according to SupportsUnicode, and ASCIISymbols otherwise.
+func DetectSymbols() Symbols {
+	if SupportsUnicode() {
+		return DefaultSymbols
+	}
+	return ASCIISymbols
+}
+
+// Theme groups the styles, status words and symbols
+// that a command-line interface uses.
+type Theme struct {
+	// Success is the style of messages that represent success.
+	Success Style `json:"success" yaml:"success"`
+	// Failure is the style of messages that represent failure.
+	Failure Style `json:"failure" yaml:"failure"`
+	// Trace is the style of outputs of low importance for the user.
+	Trace Style `json:"trace" yaml:"trace"`
+	// Important is the style of important information.
+	Important Style `json:"important" yaml:"important"`
+	// Link is the style of clickable links.
+	Link Style `json:"link" yaml:"link"`
+
+	// StatusOK is the word printed after a step that succeeded.
+	StatusOK string `json:"ok" yaml:"ok"`
+	// StatusKO is the word printed after a step that failed.
+	StatusKO string `json:"ko" yaml:"ko"`
+
+	// Symbols used along with the styles.
+	Symbols Symbols `json:"symbols" yaml:"symbols"`
+	// Syntax is the set of styles used to highlight data.
+	Syntax Syntax `json:"syntax" yaml:"syntax"`
+}
+
+var (
+	// DefaultTheme is the theme that matches the package's
+	// formatting helpers.
+	DefaultTheme = Theme{
+		Success:   Style{"green", "bold"},
+		Failure:   Style{"red", "bold"},
+		Trace:     Style{"hi-white", "faint"},
+		Important: Style{"bold"},
+		Link:      Style{"blue", "underline"},
+		StatusOK:  "ok",
+		StatusKO:  "ko",
+		Symbols:   DefaultSymbols,
+		Syntax: Syntax{
+			Key:    Style{"cyan"},
+			String: Style{"green"},
+			Number: Style{"yellow"},
+			Bool:   Style{"magenta"},
+			Null:   Style{"hi-black"},
+		},
+	}
+
+	// HighContrastTheme uses bright colors and no faint
+	// text, to be easier to read.
+	HighContrastTheme = Theme{
+		Success:   Style{"hi-green", "bold"},
+		Failure:   Style{"hi-red", "bold"},
+		Trace:     Style{"white"},
+		Important: Style{"hi-white", "bold"},
+		Link:      Style{"hi-cyan", "underline"},
+		StatusOK:  "ok",
+		StatusKO:  "ko",
+		Symbols:   DefaultSymbols,
+		Syntax: Syntax{
+			Key:    Style{"hi-cyan", "bold"},
+			String: Style{"hi-green"},
+			Number: Style{"hi-yellow"},
+			Bool:   Style{"hi-magenta"},
+			Null:   Style{"white"},
+		},
+	}
+
+	// MonochromeTheme uses no colors, only font weights
+	// and decorations.
+	MonochromeTheme = Theme{
+		Success:   Style{"bold"},
+		Failure:   Style{"bold", "reverse"},
+		Trace:     Style{"faint"},
+		Important: Style{"bold"},
+		Link:      Style{"underline"},
+		StatusOK:  "ok",
+		StatusKO:  "ko",
+		Symbols:   DefaultSymbols,
+		Syntax: Syntax{
+			Key:  Style{"bold"},
+			Bool: Style{"italic"},
+			Null: Style{"faint"},
+		},
+	}
+
+	// LightTheme is meant for terminals with a light background,
+	// on which the default theme's faint white text is hard to read.
+	LightTheme = Theme{
+		Success:   Style{"green", "bold"},
+		Failure:   Style{"red", "bold"},
+		Trace:     Style{"hi-black"},
+		Important: Style{"bold"},
+		Link:      Style{"blue", "underline"},
+		StatusOK:  "ok",
+		StatusKO:  "ko",
+		Symbols:   DefaultSymbols,
+		Syntax: Syntax{
+			Key:    Style{"blue"},
+			String: Style{"green"},
+			Number: Style{"magenta"},
+			Bool:   Style{"magenta"},
+			Null:   Style{"hi-black"},
+		},
+	}
+)
+
+// Themes are the built-in themes, by name.
+var Themes = map[string]Theme{
+	"default":       DefaultTheme,
+	"high-contrast": HighContrastTheme,
+	"monochrome":    MonochromeTheme,
+	"light":         LightTheme,
+}
+
+// Validate returns an error if the theme contains unknown style attributes.
+func (t Theme) Validate() error {
+	styles := []struct {
+		name  string
+		style Style
+	}{
+		{"success", t.Success},
+		{"failure", t.Failure},
+		{"trace", t.Trace},
+		{"important", t.Important},
+		{"link", t.Link},
+		{"syntax key", t.Syntax.Key},
+		{"syntax string", t.Syntax.String},
+		{"syntax number", t.Syntax.Number},
+		{"syntax bool", t.Syntax.Bool},
+		{"syntax null", t.Syntax.Null},
+	}
+
+	for _, s := range styles {
+		if err := s.style.Validate(); err != nil {
+			return fmt.Errorf("invalid %s style: %v", s.name, err)
+		}
+	}
+	return nil
+}
+
+// clone returns a copy of the theme that does not
+// share the backing arrays of its styles.
+func (t Theme) clone() Theme {
+	clone := t
+	styles := []*Style{
+		&clone.Success, &clone.Failure, &clone.Trace, &clone.Important, &clone.Link,
+		&clone.Syntax.Key, &clone.Syntax.String, &clone.Syntax.Number, &clone.Syntax.Bool, &clone.Syntax.Null,
+	}
+	for _, s := range styles {
+		*s = append(Style(nil), *s...)
+	}
+	return clone
+}
+
+// LoadTheme loads a theme from a JSON or YAML file, depending on its
+// extension. The file can set an `extends` field to the name of one of
+// the built-in themes, on top of which it is loaded. Otherwise, it is
+// loaded on top of the default theme. Either way, fields that are not
+// set in the file keep the values of the theme that it extends.
+//
+// For example, in YAML:
+//
+//	extends: high-contrast
+//	success: [cyan, bold]
+//	ok: done
+//	symbols:
+//	  check: "+"
+func LoadTheme(path string) (Theme, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Theme{}, fmt.Errorf("unable to read theme file: %v", err)
+	}
+
+	var unmarshal func([]byte, interface{}) error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		unmarshal = json.Unmarshal
+	case ".yaml", ".yml":
+		unmarshal = yaml.Unmarshal
+	default:
+		return Theme{}, fmt.Errorf("unsupported theme file extension %q", filepath.Ext(path))
+	}
+
+	var base struct {
+		Extends string `json:"extends" yaml:"extends"`
+	}
+	if err := unmarshal(content, &base); err != nil {
+		return Theme{}, fmt.Errorf("unable to parse theme file: %v", err)
+	}
+
+	theme := DefaultTheme
+	if base.Extends != "" {
+		var ok bool
+		theme, ok = Themes[base.Extends]
+		if !ok {
+			return Theme{}, fmt.Errorf("unknown theme %q", base.Extends)
+		}
+	}
+
+	// Decode the file on top of a copy of the base theme, so
+	// that the built-in themes are never modified.
+	theme = theme.clone()
+	if err := unmarshal(content, &theme); err != nil {
+		return Theme{}, fmt.Errorf("unable to parse theme file: %v", err)
+	}
+
+	if err := theme.Validate(); err != nil {
+		return Theme{}, err
+	}
+
+	return theme, nil
+}
@@ -0,0 +1,96 @@
+package style
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadTheme(t *testing.T) {
+	dir, err := ioutil.TempDir("", "style")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	custom := DefaultTheme
+	custom.Success = Style{"cyan", "bold"}
+	custom.StatusOK = "done"
+	custom.Symbols.Check = "+"
+
+	highContrast := HighContrastTheme
+	highContrast.Trace = Style{"hi-black"}
+
+	testCases := []struct {
+		desc          string
+		file          string
+		content       string
+		expected      Theme
+		expectedError string
+	}{
+		{
+			desc:     "yaml on top of default theme",
+			file:     "theme.yaml",
+			content:  "success: [cyan, bold]\nok: done\nsymbols:\n  check: \"+\"\n",
+			expected: custom,
+		},
+		{
+			desc:     "json on top of default theme",
+			file:     "theme.json",
+			content:  `{"success": ["cyan", "bold"], "ok": "done", "symbols": {"check": "+"}}`,
+			expected: custom,
+		},
+		{
+			desc:     "extends built-in theme",
+			file:     "theme.yml",
+			content:  "extends: high-contrast\ntrace: [hi-black]\n",
+			expected: highContrast,
+		},
+		{
+			desc:          "unknown built-in theme",
+			file:          "unknown.yml",
+			content:       "extends: neon\n",
+			expectedError: `unknown theme "neon"`,
+		},
+		{
+			desc:          "unknown attribute",
+			file:          "invalid.json",
+			content:       `{"link": ["blue", "sparkly"]}`,
+			expectedError: `invalid link style: unknown style attribute "sparkly"`,
+		},
+		{
+			desc:          "unsupported extension",
+			file:          "theme.toml",
+			content:       "ok = \"done\"",
+			expectedError: `unsupported theme file extension ".toml"`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			path := filepath.Join(dir, test.file)
+			require.NoError(t, ioutil.WriteFile(path, []byte(test.content), 0644))
+
+			theme, err := LoadTheme(path)
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, theme)
+		})
+	}
+
+	// Loading themes should never modify the built-in ones.
+	assert.Equal(t, Style{"green", "bold"}, DefaultTheme.Success)
+	assert.Equal(t, Style{"white"}, HighContrastTheme.Trace)
+}
+
+func TestBuiltInThemesAreValid(t *testing.T) {
+	for name, theme := range Themes {
+		assert.NoError(t, theme.Validate(), name)
+	}
+}
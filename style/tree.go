@@ -0,0 +1,131 @@
+package style
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Characters used to draw the branches of trees.
+type treeConnectors struct {
+	branch, last, vertical, space string
+}
+
+var (
+	unicodeConnectors = treeConnectors{"├── ", "└── ", "│   ", "    "}
+	asciiConnectors   = treeConnectors{"|-- ", "`-- ", "|   ", "    "}
+)
+
+// Tree renders hierarchical data, such as dependency graphs or
+// directory listings, with its nodes connected by branches. Since it
+// implements fmt.Stringer, a tree can be printed through a Terminal,
+// using Infoln for example, so that it is queued during steps.
+type Tree struct {
+	// Label of the node.
+	Label string
+	// Style applied to the label of the node, such as Success,
+	// Failure, or the Sprint method of a Style. If it is nil,
+	// the label is rendered as is.
+	Style func(a ...interface{}) string
+	// Children of the node.
+	Children []*Tree
+
+	// MaxDepth is the depth beyond which branches are collapsed into
+	// a single line that tells how many nodes they contain. The root
+	// is at depth 0. If it is 0, branches are never collapsed. It is
+	// only used on the root of the tree.
+	MaxDepth int
+	// ASCII makes the tree use ASCII characters for its branches,
+	// instead of UTF-8 ones. It is only used on the root of the tree.
+	// Trees created with NewTree use them if the terminal does not
+	// support UTF-8 according to SupportsUnicode.
+	ASCII bool
+}
+
+// NewTree creates a tree with the given label on its root, which
+// uses ASCII characters if the terminal does not support UTF-8.
+func NewTree(label string) *Tree {
+	return &Tree{
+		Label: label,
+		ASCII: !SupportsUnicode(),
+	}
+}
+
+// Add adds a child with the given label to the node,
+// and returns the child.
+func (t *Tree) Add(label string) *Tree {
+	child := NewTree(label)
+	t.Children = append(t.Children, child)
+	return child
+}
+
+// AddTree adds the given trees as children of the node,
+// and returns the node.
+func (t *Tree) AddTree(children ...*Tree) *Tree {
+	t.Children = append(t.Children, children...)
+	return t
+}
+
+// String renders the tree, without a trailing newline.
+func (t Tree) String() string {
+	connectors := unicodeConnectors
+	ellipsis := Ellipsis
+	if t.ASCII {
+		connectors = asciiConnectors
+		ellipsis = "..."
+	}
+
+	var lines []string
+	t.render(&lines, "", "", 0, t.MaxDepth, connectors, ellipsis)
+
+	return strings.Join(lines, "\n")
+}
+
+// render appends the lines of the node and its descendants. The first
+// line of its label is prefixed with first, and the lines that follow
+// with rest.
+func (t Tree) render(lines *[]string, first, rest string, depth, maxDepth int, connectors treeConnectors, ellipsis string) {
+	label := t.Label
+	if t.Style != nil {
+		label = t.Style(label)
+	}
+
+	// Align the continuation lines of the label with its first
+	// line, or after the branch that leads to its children.
+	continuation := rest
+	if len(t.Children) > 0 {
+		continuation = rest + connectors.vertical
+	}
+	for i, line := range strings.Split(label, "\n") {
+		if i == 0 {
+			*lines = append(*lines, first+line)
+		} else {
+			*lines = append(*lines, continuation+line)
+		}
+	}
+
+	if len(t.Children) == 0 {
+		return
+	}
+
+	if maxDepth > 0 && depth >= maxDepth {
+		*lines = append(*lines, rest+connectors.last+fmt.Sprintf("%s (%d more)", ellipsis, t.descendants()))
+		return
+	}
+
+	for i, child := range t.Children {
+		if i == len(t.Children)-1 {
+			child.render(lines, rest+connectors.last, rest+connectors.space, depth+1, maxDepth, connectors, ellipsis)
+		} else {
+			child.render(lines, rest+connectors.branch, rest+connectors.vertical, depth+1, maxDepth, connectors, ellipsis)
+		}
+	}
+}
+
+// descendants returns the number of descendants of the node.
+func (t Tree) descendants() int {
+	count := len(t.Children)
+	for _, child := range t.Children {
+		count += child.descendants()
+	}
+	return count
+}
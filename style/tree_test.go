@@ -0,0 +1,115 @@
+package style
+
+import (
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTree(t *testing.T) {
+	defer setLocale(t, "en_US.UTF-8")()
+
+	newTree := func() *Tree {
+		tree := NewTree("app")
+		lib := tree.Add("lib")
+		lib.Add("a.go")
+		lib.Add("b.go").Add("generated.go")
+		tree.Add("main.go")
+		return tree
+	}
+
+	testCases := []struct {
+		desc     string
+		tree     func() *Tree
+		expected string
+	}{
+		{
+			desc: "single node",
+			tree: func() *Tree {
+				return NewTree("app")
+			},
+			expected: "app",
+		},
+		{
+			desc: "nested nodes",
+			tree: newTree,
+			expected: "app\n" +
+				"├── lib\n" +
+				"│   ├── a.go\n" +
+				"│   └── b.go\n" +
+				"│       └── generated.go\n" +
+				"└── main.go",
+		},
+		{
+			desc: "ASCII",
+			tree: func() *Tree {
+				tree := newTree()
+				tree.ASCII = true
+				return tree
+			},
+			expected: "app\n" +
+				"|-- lib\n" +
+				"|   |-- a.go\n" +
+				"|   `-- b.go\n" +
+				"|       `-- generated.go\n" +
+				"`-- main.go",
+		},
+		{
+			desc: "collapsed branches",
+			tree: func() *Tree {
+				tree := newTree()
+				tree.MaxDepth = 1
+				return tree
+			},
+			expected: "app\n" +
+				"├── lib\n" +
+				"│   └── … (3 more)\n" +
+				"└── main.go",
+		},
+		{
+			desc: "multi-line labels",
+			tree: func() *Tree {
+				tree := NewTree("app")
+				tree.AddTree(&Tree{Label: "lib\n(vendored)", Children: []*Tree{NewTree("a.go")}}, NewTree("main.go\n(entrypoint)"))
+				return tree
+			},
+			expected: "app\n" +
+				"├── lib\n" +
+				"│   │   (vendored)\n" +
+				"│   └── a.go\n" +
+				"└── main.go\n" +
+				"    (entrypoint)",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.tree().String())
+		})
+	}
+}
+
+func TestTreeStyle(t *testing.T) {
+	color.NoColor = false
+	defer func() {
+		color.NoColor = true
+	}()
+
+	tree := NewTree("app")
+	tree.Add("ok").Style = Success
+	tree.Add("broken").Style = Style{"red"}.Sprint
+
+	assert.Equal(t, "app\n"+
+		"├── "+Success("ok")+"\n"+
+		"└── \x1b[31mbroken\x1b[0m", tree.String())
+}
+
+func TestTreeLocale(t *testing.T) {
+	defer setLocale(t, "C")()
+
+	tree := NewTree("app")
+	tree.Add("main.go")
+
+	assert.Equal(t, "app\n`-- main.go", tree.String())
+}
@@ -0,0 +1,257 @@
+package style
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// Ellipsis is appended to messages that are truncated.
+const Ellipsis = "\xe2\x80\xa6"
+
+// Width returns the number of columns that a message takes when
+// it is displayed on a terminal. ANSI escape sequences take no
+// space, and wide characters such as CJK characters and most
+// emojis take two columns.
+func Width(message string) int {
+	return runewidth.StringWidth(Strip(message))
+}
+
+// hyperlinkEnd closes OSC 8 hyperlinks.
+const hyperlinkEnd = "\x1b]8;;\x1b\\"
+
+// isHyperlink returns whether or not an escape sequence starts or ends
+// a hyperlink, and if it does, whether it starts one.
+func isHyperlink(sequence string) (link, start bool) {
+	if !strings.HasPrefix(sequence, "\x1b]8;") {
+		return false, false
+	}
+
+	// Hyperlinks look like `OSC 8 ; params ; URL ST`,
+	// and are ended by the same sequence without a URL.
+	body := strings.TrimSuffix(strings.TrimSuffix(strings.TrimPrefix(sequence, "\x1b]8;"), "\x07"), "\x1b\\")
+	parts := strings.SplitN(body, ";", 2)
+	return true, len(parts) == 2 && parts[1] != ""
+}
+
+// Truncate shortens a message so that it takes at most the given
+// number of columns, including the tail that is appended to it if it
+// is truncated, such as Ellipsis. ANSI escape sequences are kept, and
+// styles are reset after the tail if the message contained any.
+func Truncate(message string, width int, tail string) string {
+	if Width(message) <= width {
+		return message
+	}
+
+	available := width - Width(tail)
+	if available < 0 {
+		return runewidth.Truncate(tail, width, "")
+	}
+
+	var (
+		result  strings.Builder
+		used    int
+		escaped bool
+		linked  bool
+	)
+
+	for len(message) > 0 {
+		// Copy escape sequences as they are.
+		if strings.HasPrefix(message, "\x1b") {
+			if loc := ansiPattern.FindStringIndex(message); loc != nil && loc[0] == 0 {
+				sequence := message[:loc[1]]
+				if link, start := isHyperlink(sequence); link {
+					linked = start
+				} else {
+					escaped = true
+				}
+
+				result.WriteString(sequence)
+				message = message[loc[1]:]
+				continue
+			}
+		}
+
+		r, size := utf8.DecodeRuneInString(message)
+		runeWidth := runewidth.RuneWidth(r)
+		if used+runeWidth > available {
+			break
+		}
+
+		result.WriteString(message[:size])
+		message = message[size:]
+		used += runeWidth
+	}
+
+	result.WriteString(tail)
+	if linked {
+		result.WriteString(hyperlinkEnd)
+	}
+	if escaped {
+		result.WriteString("\x1b[0m")
+	}
+
+	return result.String()
+}
+
+// pad pads a message with spaces on its right so
+// that it takes at least the given number of columns.
+func pad(message string, width int) string {
+	if padding := width - Width(message); padding > 0 {
+		return message + strings.Repeat(" ", padding)
+	}
+	return message
+}
+
+// Wrap wraps the lines of a message so that they take at most the given
+// number of columns, by breaking them between words. Words that are
+// longer than a line are broken where they reach its end. The leading
+// spaces of each line are kept on its first wrapped line. ANSI escape
+// sequences take no space, and styles that span several lines are reset
+// at the end of each line and applied again on the next one, so that
+// lines can be indented or decorated without being styled. If width is
+// 0 or less, the message is returned as is.
+func Wrap(message string, width int) string {
+	if width <= 0 {
+		return message
+	}
+
+	var wrapped []string
+	for _, line := range strings.Split(message, "\n") {
+		wrapped = append(wrapped, wrapLine(line, width)...)
+	}
+	return strings.Join(carryStyles(wrapped), "\n")
+}
+
+// carryStyles resets the styles and closes the hyperlink that are still
+// active at the end of each line, and applies them again at the start
+// of the next one. Empty lines are left as they are.
+func carryStyles(lines []string) []string {
+	var (
+		active []string
+		link   string
+	)
+
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
+
+		opening := link + strings.Join(active, "")
+		for _, sequence := range ansiPattern.FindAllString(line, -1) {
+			if isLink, start := isHyperlink(sequence); isLink {
+				link = ""
+				if start {
+					link = sequence
+				}
+				continue
+			}
+
+			switch {
+			case sequence == "\x1b[0m" || sequence == "\x1b[m":
+				active = nil
+			case strings.HasPrefix(sequence, "\x1b[") && strings.HasSuffix(sequence, "m"):
+				active = append(active, sequence)
+			}
+		}
+
+		lines[i] = opening + line
+		if len(active) > 0 {
+			lines[i] += "\x1b[0m"
+		}
+		if link != "" {
+			lines[i] += hyperlinkEnd
+		}
+	}
+	return lines
+}
+
+// wrapLine wraps a single line.
+func wrapLine(line string, width int) []string {
+	if Width(line) <= width {
+		return []string{line}
+	}
+
+	var (
+		lines        []string
+		current      strings.Builder
+		currentWidth int
+		empty        = true
+	)
+
+	indent := line[:len(line)-len(strings.TrimLeft(line, " "))]
+	if Width(indent) < width {
+		current.WriteString(indent)
+		currentWidth = Width(indent)
+	}
+
+	flush := func() {
+		lines = append(lines, current.String())
+		current.Reset()
+		currentWidth = 0
+		empty = true
+	}
+
+	for _, word := range strings.Fields(line) {
+		wordWidth := Width(word)
+		if !empty && currentWidth+1+wordWidth > width {
+			flush()
+		}
+		if !empty {
+			current.WriteString(" ")
+			currentWidth++
+		}
+
+		// Break words that don't fit on a line of their own.
+		for currentWidth+wordWidth > width {
+			head, tail := splitWidth(word, width-currentWidth)
+			current.WriteString(head)
+			flush()
+
+			word = tail
+			wordWidth = Width(word)
+		}
+
+		current.WriteString(word)
+		currentWidth += wordWidth
+		empty = false
+	}
+
+	if !empty {
+		flush()
+	}
+	return lines
+}
+
+// splitWidth splits a message after the given number of columns, keeping
+// ANSI escape sequences. At least one character is kept in the head, so
+// that messages can always be split.
+func splitWidth(message string, width int) (string, string) {
+	var (
+		head strings.Builder
+		used int
+	)
+
+	for len(message) > 0 {
+		if strings.HasPrefix(message, "\x1b") {
+			if loc := ansiPattern.FindStringIndex(message); loc != nil && loc[0] == 0 {
+				head.WriteString(message[:loc[1]])
+				message = message[loc[1]:]
+				continue
+			}
+		}
+
+		r, size := utf8.DecodeRuneInString(message)
+		runeWidth := runewidth.RuneWidth(r)
+		if used > 0 && used+runeWidth > width {
+			break
+		}
+
+		head.WriteString(message[:size])
+		message = message[size:]
+		used += runeWidth
+	}
+
+	return head.String(), message
+}
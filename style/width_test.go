@@ -0,0 +1,160 @@
+package style
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWidth(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		message  string
+		expected int
+	}{
+		{
+			desc:     "ASCII",
+			message:  "hello",
+			expected: 5,
+		},
+		{
+			desc:     "styled",
+			message:  "\x1b[32;1mok\x1b[0m",
+			expected: 2,
+		},
+		{
+			desc:     "CJK",
+			message:  "日本語",
+			expected: 6,
+		},
+		{
+			desc:     "emoji",
+			message:  "🚀 go",
+			expected: 5,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, Width(test.message))
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		message  string
+		width    int
+		tail     string
+		expected string
+	}{
+		{
+			desc:     "fits",
+			message:  "hello",
+			width:    5,
+			tail:     Ellipsis,
+			expected: "hello",
+		},
+		{
+			desc:     "truncated",
+			message:  "hello world",
+			width:    6,
+			tail:     Ellipsis,
+			expected: "hello…",
+		},
+		{
+			desc:     "styles are kept and reset",
+			message:  "\x1b[1mhello\x1b[0m world",
+			width:    4,
+			tail:     "...",
+			expected: "\x1b[1mh...\x1b[0m",
+		},
+		{
+			desc:     "wide characters are not split",
+			message:  "日本語",
+			width:    4,
+			tail:     Ellipsis,
+			expected: "日…",
+		},
+		{
+			desc:     "tail wider than width",
+			message:  "hello",
+			width:    2,
+			tail:     "...",
+			expected: "..",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, Truncate(test.message, test.width, test.tail))
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		message  string
+		width    int
+		expected string
+	}{
+		{
+			desc:     "short message",
+			message:  "hello world",
+			width:    20,
+			expected: "hello world",
+		},
+		{
+			desc:     "wrapped between words",
+			message:  "the quick brown fox jumps",
+			width:    10,
+			expected: "the quick\nbrown fox\njumps",
+		},
+		{
+			desc:     "long words are broken",
+			message:  "a abcdefghij",
+			width:    4,
+			expected: "a\nabcd\nefgh\nij",
+		},
+		{
+			desc:     "existing newlines and indentation are kept",
+			message:  "title\n  some indented text",
+			width:    12,
+			expected: "title\n  some\nindented\ntext",
+		},
+		{
+			desc:     "styles take no space",
+			message:  "\x1b[1mbold\x1b[0m text here",
+			width:    9,
+			expected: "\x1b[1mbold\x1b[0m text\nhere",
+		},
+		{
+			desc:     "wide characters",
+			message:  "日本語 日本語",
+			width:    6,
+			expected: "日本語\n日本語",
+		},
+		{
+			desc:     "no width",
+			message:  "hello world",
+			width:    0,
+			expected: "hello world",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, Wrap(test.message, test.width))
+		})
+	}
+}
+
+func TestWrapCarriesStyles(t *testing.T) {
+	wrapped := Wrap("\x1b[1mbold text that wraps\x1b[0m done", 10)
+
+	assert.Equal(t, "\x1b[1mbold text\x1b[0m\n"+
+		"\x1b[1mthat wraps\x1b[0m\n"+
+		"done", wrapped)
+}
@@ -0,0 +1,42 @@
+package disgo
+
+import "github.com/Ullaakut/disgo/style"
+
+// asciiMode represents whether the terminal was explicitly
+// set to use ASCII symbols or not.
+type asciiMode int
+
+const (
+	asciiDefault asciiMode = iota
+	asciiEnabled
+	asciiDisabled
+)
+
+// WithASCII makes the terminal use ASCII symbols instead of UTF-8
+// ones, for terminals that can't display UTF-8, such as legacy
+// consoles and some CI log viewers. By default, ASCII symbols are
+// used if the user's locale does not support UTF-8. In ASCII mode,
+// the borders of tables, trees, sections and boxes, as well as the
+// bullets, quotes and rules of Markdown, are drawn with ASCII
+// characters, and the symbols of the theme returned by Theme that
+// are the default UTF-8 ones are replaced with ASCII ones.
+func WithASCII(enabled bool) func(*Terminal) {
+	return func(term *Terminal) {
+		term.ascii = asciiDisabled
+		if enabled {
+			term.ascii = asciiEnabled
+		}
+	}
+}
+
+// asciiSymbols returns whether or not the
+// terminal should use ASCII symbols.
+func (t Terminal) asciiSymbols() bool {
+	switch t.ascii {
+	case asciiEnabled:
+		return true
+	case asciiDisabled:
+		return false
+	}
+	return !style.SupportsUnicode()
+}
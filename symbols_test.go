@@ -0,0 +1,46 @@
+package disgo
+
+import (
+	"os"
+	"testing"
+
+	"github.com/Ullaakut/disgo/style"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithASCII(t *testing.T) {
+	lang, lcAll, lcCtype := os.Getenv("LANG"), os.Getenv("LC_ALL"), os.Getenv("LC_CTYPE")
+	defer func() {
+		os.Setenv("LANG", lang)
+		os.Setenv("LC_ALL", lcAll)
+		os.Setenv("LC_CTYPE", lcCtype)
+	}()
+	require.NoError(t, os.Setenv("LC_ALL", ""))
+	require.NoError(t, os.Setenv("LC_CTYPE", ""))
+
+	require.NoError(t, os.Setenv("LANG", "C"))
+	assert.Equal(t, style.ASCIISymbols, NewTerminal().Theme().Symbols)
+	assert.Equal(t, style.DefaultSymbols, NewTerminal(WithASCII(false)).Theme().Symbols)
+
+	require.NoError(t, os.Setenv("LANG", "en_US.UTF-8"))
+	term := NewTerminal(WithASCII(true), WithTheme(style.HighContrastTheme))
+	assert.Equal(t, style.ASCIISymbols, term.Theme().Symbols)
+	assert.Equal(t, style.HighContrastTheme.Success, term.Theme().Success)
+	assert.Equal(t, style.ASCIISymbols, term.Scope("plugin").Theme().Symbols)
+	assert.Equal(t, style.DefaultSymbols, NewTerminal().Theme().Symbols)
+}
+
+func TestWithASCIICustomSymbols(t *testing.T) {
+	theme := style.DefaultTheme
+	theme.Symbols.Check = "OK"
+	theme.Symbols.RightArrow = "=>"
+
+	term := NewTerminal(WithASCII(true), WithTheme(theme))
+
+	symbols := term.Theme().Symbols
+	assert.Equal(t, "OK", symbols.Check)
+	assert.Equal(t, "=>", symbols.RightArrow)
+	assert.Equal(t, "[x]", symbols.Cross)
+	assert.Equal(t, style.ASCIISymbols.LeftArrow, symbols.LeftArrow)
+}
@@ -0,0 +1,23 @@
+package disgo
+
+import "github.com/Ullaakut/disgo/style"
+
+// Table writes a table on the terminal's default writer. Its borders
+// use ASCII characters if the terminal uses ASCII symbols, regardless
+// of the table's ASCII field, and unless it has a maximum width, it is
+// shrunk to fit in the width of the terminal, minus the decoration and
+// indentation of outputs. The table itself is left as is. Like other
+// outputs, tables are queued during steps.
+func (t Terminal) Table(table *style.Table) {
+	rendered := *table
+	rendered.ASCII = t.asciiSymbols()
+	if rendered.MaxWidth == 0 {
+		rendered.MaxWidth = t.availableWidth()
+	}
+	t.Infoln(rendered)
+}
+
+// Table writes a table on the global terminal's default writer.
+func Table(table *style.Table) {
+	globalTerm.Table(table)
+}
@@ -0,0 +1,58 @@
+package disgo
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Ullaakut/disgo/style"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTable(t *testing.T) {
+	out := &bytes.Buffer{}
+	term := NewTerminal(WithDefaultOutput(out), WithColors(false), WithASCII(true))
+
+	table := style.NewTable("A", "B").AddRow(1, 2)
+	table.Borders = true
+	table.ASCII = false
+
+	term.StartStep("Listing")
+	term.Table(table)
+	term.EndStep()
+
+	assert.Equal(t, "Listing...ok\n"+
+		"  > +---+---+\n"+
+		"    | A | B |\n"+
+		"    +---+---+\n"+
+		"    | 1 | 2 |\n"+
+		"    +---+---+\n", out.String())
+	assert.False(t, table.ASCII)
+}
+
+func TestTableWordWrap(t *testing.T) {
+	columns := os.Getenv("COLUMNS")
+	defer os.Setenv("COLUMNS", columns)
+	require.NoError(t, os.Setenv("COLUMNS", "30"))
+
+	out := &bytes.Buffer{}
+	term := NewTerminal(WithDefaultOutput(out), WithColors(false), WithASCII(true), WithWordWrap(true))
+
+	table := style.NewTable("Name", "Description").
+		AddRow("disgo", "a library to output beautiful messages on the terminal")
+	table.Borders = true
+
+	term.Table(table)
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	require.Len(t, lines, 5)
+	separator := strings.Index(lines[0][1:], "+") + 1
+	for _, line := range lines {
+		assert.True(t, style.Width(line) <= 30, line)
+		assert.Equal(t, len(lines[0]), len(line), line)
+		assert.Contains(t, "+|", string(line[separator]), line)
+	}
+	assert.Equal(t, 0, table.MaxWidth)
+}
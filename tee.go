@@ -0,0 +1,324 @@
+package disgo
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Ullaakut/disgo/style"
+)
+
+// Tags used on the tee for lines that are not outputs.
+const (
+	teeTagStep   = "STEP"
+	teeTagPrompt = "PROMPT"
+)
+
+// WithTee sets a writer on which the terminal writes a copy of all of
+// its outputs, meant to be read by humans later on, such as a log file
+// attached to a support ticket. Unlike the terminal's own writers, the
+// tee receives all outputs as soon as they are produced, including debug
+// outputs when debug is disabled, as well as step status lines and
+// prompt answers. Each line is timestamped and tagged with its level,
+// and ANSI escape sequences are stripped from it. Outputs that don't end
+// with a newline are kept until their line is complete, so that lines
+// are written on the tee the way they appear on screen. They are also
+// written when a step starts or ends, and when the terminal is flushed,
+// so Flush should be called before the program exits in order not to
+// lose the last line if it is incomplete.
+func WithTee(writer io.Writer) func(*Terminal) {
+	return func(term *Terminal) {
+		term.teeOutput = writer
+		term.teeLines = &teeBuffer{}
+	}
+}
+
+// WithLogFile makes the terminal write a copy of all of its outputs to
+// the file at the given path, as described in WithTee. The file is never
+// closed, and errors that occur while writing to it are ignored. In order
+// to close the file, check for errors or enable rotation, use WithTee
+// with a LogFile instead.
+func WithLogFile(path string) func(*Terminal) {
+	return WithTee(&LogFile{
+		Path: path,
+	})
+}
+
+// teeBuffer keeps the lines of each level that were only partially
+// written, until they are complete. It is shared between a terminal
+// and its children, like the lines they write on screen.
+type teeBuffer struct {
+	mu      sync.Mutex
+	pending []teeLine
+}
+
+// teeLine is a line of the tee, along with the prefix
+// that was computed when it was started.
+type teeLine struct {
+	tag    string
+	prefix string
+	text   string
+}
+
+// take removes the pending line of the given tag from
+// the buffer and returns it, if there is one.
+func (b *teeBuffer) take(tag string) (teeLine, bool) {
+	for i, line := range b.pending {
+		if line.tag == tag {
+			b.pending = append(b.pending[:i], b.pending[i+1:]...)
+			return line, true
+		}
+	}
+	return teeLine{}, false
+}
+
+// teePrefix returns the prefix of tee lines that
+// are started right now with the given tag.
+func (t Terminal) teePrefix(tag, indent string) string {
+	prefix := t.now().Format(time.RFC3339) + " " + fmt.Sprintf("%-6s", tag) + " "
+	if t.prefix != "" {
+		prefix += style.Strip(t.prefix) + " "
+	}
+	return prefix + indent
+}
+
+// teeRecord returns a line of the tee as it is written, with ANSI
+// escape sequences stripped, except for the URLs of hyperlinks, and
+// secrets masked.
+func (t Terminal) teeRecord(line teeLine) string {
+	return line.prefix + t.redactor.Redact(style.Strip(style.StripHyperlinks(line.text))) + "\n"
+}
+
+// tee writes a record, such as a step status line, on the terminal's
+// tee, if it has one. Each line of the record is prefixed with the
+// current time, the given tag and indent. Lines of outputs that are
+// still incomplete are written first, as they are.
+func (t Terminal) tee(tag, indent, content string) {
+	if t.teeOutput == nil {
+		return
+	}
+
+	var record strings.Builder
+	if t.teeLines != nil {
+		t.teeLines.mu.Lock()
+		defer t.teeLines.mu.Unlock()
+
+		t.teePending(&record)
+	}
+
+	prefix := t.teePrefix(tag, indent)
+	for _, text := range strings.Split(strings.TrimSuffix(content, "\n"), "\n") {
+		record.WriteString(t.teeRecord(teeLine{prefix: prefix, text: text}))
+	}
+
+	// Write the whole record at once, so that records
+	// from different terminals are not interleaved.
+	io.WriteString(t.teeOutput, record.String())
+}
+
+// teePending writes the lines of outputs that are still incomplete
+// on the record, as they are, and removes them from the buffer. The
+// buffer must be locked by the caller.
+func (t Terminal) teePending(record *strings.Builder) {
+	for _, line := range t.teeLines.pending {
+		record.WriteString(t.teeRecord(line))
+	}
+	t.teeLines.pending = nil
+}
+
+// Flush writes the lines of outputs that are still incomplete on the
+// terminal's tee, if it has one, as if they were complete. It should
+// be called before the program exits, or before closing the tee's
+// writer, so that an output that doesn't end with a newline is not
+// lost.
+func (t Terminal) Flush() {
+	if t.teeOutput == nil || t.teeLines == nil {
+		return
+	}
+
+	t.teeLines.mu.Lock()
+	defer t.teeLines.mu.Unlock()
+
+	var record strings.Builder
+	t.teePending(&record)
+	if record.Len() > 0 {
+		io.WriteString(t.teeOutput, record.String())
+	}
+}
+
+// Flush writes the lines of outputs that are still incomplete
+// on the global terminal's tee, if it has one.
+func Flush() {
+	globalTerm.Flush()
+}
+
+// teeLevel writes an output of the given level on the terminal's
+// tee, if it has one. Complete lines are written right away, while
+// the end of the output is kept until a newline completes it.
+func (t Terminal) teeLevel(level Level, indent, content string) {
+	tag := strings.ToUpper(level.String())
+	if t.teeOutput == nil || t.teeLines == nil {
+		t.tee(tag, indent, content)
+		return
+	}
+	if content == "" {
+		return
+	}
+
+	t.teeLines.mu.Lock()
+	defer t.teeLines.mu.Unlock()
+
+	var record strings.Builder
+	line, started := t.teeLines.take(tag)
+	for content != "" {
+		if !started {
+			line = teeLine{tag: tag, prefix: t.teePrefix(tag, indent)}
+			started = true
+		}
+
+		end := strings.IndexByte(content, '\n')
+		if end < 0 {
+			line.text += content
+			t.teeLines.pending = append(t.teeLines.pending, line)
+			break
+		}
+
+		line.text += content[:end]
+		record.WriteString(t.teeRecord(line))
+		content = content[end+1:]
+		started = false
+	}
+
+	if record.Len() > 0 {
+		io.WriteString(t.teeOutput, record.String())
+	}
+}
+
+// LogFile is a writer that appends to a file, which is created if
+// it does not exist. It can optionally rotate the file once it
+// reaches a given size.
+//
+// A LogFile is safe for concurrent use.
+type LogFile struct {
+	// Path of the file to write to.
+	Path string
+
+	// MaxSize is the size in bytes above which the file is rotated.
+	// If it is zero or negative, the file is never rotated.
+	MaxSize int64
+
+	// MaxBackups is the number of rotated files to keep. Rotated
+	// files are named after Path, with a `.1`, `.2`, etc. suffix,
+	// `.1` being the most recent one. If it is zero or negative,
+	// one rotated file is kept.
+	MaxBackups int
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+	err  error
+}
+
+// Write appends p to the file, opening it first if needed, and
+// rotating it if writing p would make it exceed its maximum size.
+func (l *LogFile) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		if err := l.open(); err != nil {
+			l.err = err
+			return 0, err
+		}
+	}
+
+	if l.MaxSize > 0 && l.size > 0 && l.size+int64(len(p)) > l.MaxSize {
+		if err := l.rotate(); err != nil {
+			l.err = err
+			return 0, err
+		}
+	}
+
+	n, err := l.file.Write(p)
+	l.size += int64(n)
+	if err != nil {
+		l.err = err
+	}
+	return n, err
+}
+
+// Err returns the last error that occurred while opening, rotating
+// or writing to the file, if any. Since terminals don't report the
+// errors of their tee, it can be used to check that the log file was
+// written properly, for example right before closing it.
+func (l *LogFile) Err() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.err
+}
+
+// Close closes the file. Writing to the LogFile after closing
+// it opens the file again.
+func (l *LogFile) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
+
+func (l *LogFile) open() error {
+	file, err := os.OpenFile(l.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("unable to open log file: %v", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("unable to read log file size: %v", err)
+	}
+
+	l.file = file
+	l.size = info.Size()
+	return nil
+}
+
+// rotate shifts the existing backups, moves the current file
+// to the first backup and opens a new file.
+func (l *LogFile) rotate() error {
+	if err := l.file.Close(); err != nil {
+		return fmt.Errorf("unable to close log file: %v", err)
+	}
+	l.file = nil
+
+	backups := l.MaxBackups
+	if backups <= 0 {
+		backups = 1
+	}
+
+	for i := backups - 1; i > 0; i-- {
+		// Missing backups are expected when the file
+		// was not rotated enough times yet.
+		_ = os.Rename(l.backupPath(i), l.backupPath(i+1))
+	}
+
+	if err := os.Rename(l.Path, l.backupPath(1)); err != nil {
+		return fmt.Errorf("unable to rotate log file: %v", err)
+	}
+
+	return l.open()
+}
+
+func (l *LogFile) backupPath(index int) string {
+	return fmt.Sprintf("%s.%d", l.Path, index)
+}
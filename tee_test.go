@@ -0,0 +1,178 @@
+package disgo
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithTee(t *testing.T) {
+	defaultOut := &bytes.Buffer{}
+	errorOut := &bytes.Buffer{}
+	teeOut := &bytes.Buffer{}
+
+	term := NewTerminal(
+		WithDefaultOutput(defaultOut),
+		WithErrorOutput(errorOut),
+		WithReader(strings.NewReader("y\n")),
+		WithTee(teeOut),
+	)
+	term.clock = func() time.Time {
+		return time.Date(2019, 1, 1, 12, 0, 0, 0, time.UTC)
+	}
+	term.RegisterSecret("hunter2")
+
+	term.Infoln("\x1b[1mimportant\x1b[0m")
+	term.Debugln("hidden on screen")
+	term.StartStep("Simulated task #1")
+	term.Infoln("two\nlines")
+	term.Errorln("using hunter2")
+	term.EndStep()
+	term.StartStep("Simulated task #2")
+	_ = term.FailStep(errors.New("dummy error"))
+	term.Scope("plugin").Infoln("scoped")
+	_, err := term.Confirm(Confirmation{Label: "Continue?"})
+	require.NoError(t, err)
+
+	assert.NotContains(t, defaultOut.String(), "hidden on screen")
+	assert.Equal(t, "2019-01-01T12:00:00Z INFO   important\n"+
+		"2019-01-01T12:00:00Z DEBUG  hidden on screen\n"+
+		"2019-01-01T12:00:00Z STEP   Simulated task #1...\n"+
+		"2019-01-01T12:00:00Z INFO     > two\n"+
+		"2019-01-01T12:00:00Z INFO     > lines\n"+
+		"2019-01-01T12:00:00Z ERROR    > using ****\n"+
+		"2019-01-01T12:00:00Z STEP   Simulated task #1...ok\n"+
+		"2019-01-01T12:00:00Z STEP   Simulated task #2...\n"+
+		"2019-01-01T12:00:00Z STEP   Simulated task #2...ko: dummy error\n"+
+		"2019-01-01T12:00:00Z INFO   [plugin] scoped\n"+
+		"2019-01-01T12:00:00Z PROMPT Continue? [y/n] y\n", teeOut.String())
+}
+
+func TestLogFileRotation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "disgo")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "output.log")
+	logFile := &LogFile{
+		Path:       path,
+		MaxSize:    10,
+		MaxBackups: 2,
+	}
+	defer logFile.Close()
+
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		_, err := logFile.Write([]byte(line))
+		require.NoError(t, err)
+	}
+
+	content, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "fourth\n", string(content))
+
+	content, err = ioutil.ReadFile(path + ".1")
+	require.NoError(t, err)
+	assert.Equal(t, "third\n", string(content))
+
+	content, err = ioutil.ReadFile(path + ".2")
+	require.NoError(t, err)
+	assert.Equal(t, "second\n", string(content))
+
+	_, err = os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestLogFileAppends(t *testing.T) {
+	dir, err := ioutil.TempDir("", "disgo")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "output.log")
+	require.NoError(t, ioutil.WriteFile(path, []byte("existing\n"), 0644))
+
+	term := NewTerminal(WithDefaultOutput(ioutil.Discard), WithLogFile(path))
+	term.Infoln("appended")
+
+	content, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(content), "existing\n"))
+	assert.True(t, strings.HasSuffix(string(content), " appended\n"))
+}
+
+func TestTeePartialLines(t *testing.T) {
+	teeOut := &bytes.Buffer{}
+
+	term := NewTerminal(WithDefaultOutput(ioutil.Discard), WithErrorOutput(ioutil.Discard), WithTee(teeOut))
+	term.clock = fakeClock(time.Date(2019, 1, 1, 12, 0, 0, 0, time.UTC))
+	term.RegisterSecret("hunter2")
+
+	// Lines should be written once they are complete, with the
+	// time at which they started, even if secrets are split.
+	term.Info("a")
+	term.Errorln("an error")
+	term.Info(" password: hunt")
+	term.Infoln("er2")
+	term.Info("unfinished")
+	term.StartStep("Simulated task #1")
+	term.EndStep()
+
+	assert.Equal(t, "2019-01-01T12:00:01Z ERROR  an error\n"+
+		"2019-01-01T12:00:00Z INFO   a password: ****\n"+
+		"2019-01-01T12:00:02Z INFO   unfinished\n"+
+		"2019-01-01T12:00:03Z STEP   Simulated task #1...\n"+
+		"2019-01-01T12:00:04Z STEP   Simulated task #1...ok\n", teeOut.String())
+}
+
+func TestTeeFlush(t *testing.T) {
+	teeOut := &bytes.Buffer{}
+
+	term := NewTerminal(WithDefaultOutput(ioutil.Discard), WithTee(teeOut))
+	term.clock = fakeClock(time.Date(2019, 1, 1, 12, 0, 0, 0, time.UTC))
+
+	term.Infoln("done")
+	term.Info("last line")
+	assert.Equal(t, "2019-01-01T12:00:00Z INFO   done\n", teeOut.String())
+
+	term.Flush()
+	term.Flush()
+
+	assert.Equal(t, "2019-01-01T12:00:00Z INFO   done\n"+
+		"2019-01-01T12:00:01Z INFO   last line\n", teeOut.String())
+}
+
+func TestLogFileErr(t *testing.T) {
+	dir, err := ioutil.TempDir("", "disgo")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	logFile := &LogFile{
+		Path: filepath.Join(dir, "missing", "output.log"),
+	}
+	defer logFile.Close()
+
+	term := NewTerminal(WithDefaultOutput(ioutil.Discard), WithTee(logFile))
+	term.Infoln("lost")
+
+	assert.Error(t, logFile.Err())
+}
+
+func TestTeeHyperlinks(t *testing.T) {
+	teeOut := &bytes.Buffer{}
+
+	term := NewTerminal(WithDefaultOutput(ioutil.Discard), WithTee(teeOut))
+	term.clock = func() time.Time {
+		return time.Date(2019, 1, 1, 12, 0, 0, 0, time.UTC)
+	}
+
+	term.Infoln("See", "\x1b]8;;https://example.com\x1b\\the docs\x1b]8;;\x1b\\")
+
+	assert.Equal(t, "2019-01-01T12:00:00Z INFO   See the docs (https://example.com)\n", teeOut.String())
+}
@@ -5,13 +5,16 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"time"
 
+	"github.com/Ullaakut/disgo/style"
 	"github.com/fatih/color"
 )
 
 var globalTerm *Terminal
 
 func init() {
+	applyColorEnvironment()
 	globalTerm = NewTerminal()
 }
 
@@ -30,6 +33,9 @@ type Terminal struct {
 	// progress, outputs are queued and will be printed once
 	// the task is over.
 	step *step
+	// Terminal from which this terminal was created using With
+	// or Scope. If it is set, the current step is the parent's.
+	parent *Terminal
 
 	// Whether or not Debug outputs are enabled. If this is
 	// disabled, Debug outputs are not shown to the user.
@@ -42,8 +48,72 @@ type Terminal struct {
 	// return default values. This can be useful for running this code
 	// outside of a TTY for example.
 	interactive bool
+
+	// Scripted answers to prompts, by key or label.
+	answers map[string]string
+	// Prefix of the environment variables from which
+	// answers to prompts are read, if not empty.
+	answersEnvPrefix string
+	// Error that occurred while loading scripted answers,
+	// which is returned by all prompts.
+	answersErr error
+	// Recorded session whose answers are fed back into
+	// prompts before scripted answers, if not nil.
+	replay *Replay
+
+	// Whether colors were explicitly enabled or disabled on
+	// this terminal. If they were disabled, all ANSI escape
+	// sequences are stripped from its outputs.
+	colors colorMode
+	// Theme used to style step statuses and queued
+	// outputs. If it is nil, the default theme is used.
+	theme *style.Theme
+	// Whether ASCII symbols were explicitly enabled or
+	// disabled on this terminal.
+	ascii asciiMode
+	// Whether outputs are wrapped to fit in the
+	// width of the terminal.
+	wordWrap bool
+
+	// Masks secrets in all of the terminal's outputs.
+	redactor *Redactor
+
+	// Writer on which a plain copy of all outputs is written,
+	// regardless of their level. It can be nil.
+	teeOutput io.Writer
+	// Lines of outputs that were only partially
+	// written on the tee.
+	teeLines *teeBuffer
+	// Records everything that the terminal prints, in
+	// the asciicast format, if not nil.
+	cast *castRecorder
+	// Functions that are called with every event that
+	// happens on the terminal.
+	handlers []func(Event)
+
+	// Prefix that is prepended to every line that the terminal
+	// writes, if not empty.
+	prefix string
+	// Layout used to prepend a timestamp to every line that the
+	// terminal writes. If it is empty, no timestamp is written.
+	timestampLayout string
+	// Keeps track of which writers are in the middle of a line,
+	// so that lines are only decorated once.
+	lines *lineTracker
+	// Returns the current time. It is used to timestamp outputs.
+	clock func() time.Time
 }
 
+// colorMode represents whether colors were explicitly enabled
+// or disabled on a terminal.
+type colorMode int
+
+const (
+	colorsDefault colorMode = iota
+	colorsEnabled
+	colorsDisabled
+)
+
 // NewTerminal creates a new Terminal.
 func NewTerminal(options ...func(*Terminal)) *Terminal {
 	term := Terminal{
@@ -51,6 +121,9 @@ func NewTerminal(options ...func(*Terminal)) *Terminal {
 		errorOutput:   os.Stderr,
 		reader:        bufio.NewReader(os.Stdin),
 		interactive:   true,
+		lines:         &lineTracker{},
+		clock:         time.Now,
+		redactor:      NewRedactor(),
 	}
 
 	for _, option := range options {
@@ -90,11 +163,20 @@ func WithReader(reader io.Reader) func(*Terminal) {
 }
 
 // WithColors sets the use of colors in the terminal. By default, whether or not
-// colors are enabled depends on the user's TTY, but this option can be used
-// to force colors to be enabled or disabled.
+// colors are enabled depends on the environment and on whether each of the
+// terminal's writers is a TTY, but this option can be used to force colors
+// to be enabled or disabled.
+//
+// When used with Terminal.With or Terminal.Scope, it only affects
+// the created terminal. Note that in that case, colors can only be
+// enabled if they are not disabled process-wide.
 func WithColors(enabled bool) func(*Terminal) {
-	return func(_ *Terminal) {
+	return func(term *Terminal) {
 		color.NoColor = !enabled
+		term.colors = colorsDisabled
+		if enabled {
+			term.colors = colorsEnabled
+		}
 	}
 }
 
@@ -105,6 +187,30 @@ func WithInteractive(enabled bool) func(*Terminal) {
 	}
 }
 
+// WithTimestamps makes the terminal prepend a timestamp formatted
+// with the given layout to every line it writes. Outputs that are
+// queued during a step are timestamped when they are produced, not
+// when the step ends. An empty layout disables timestamps.
+func WithTimestamps(layout string) func(*Terminal) {
+	return func(term *Terminal) {
+		term.timestampLayout = layout
+		if term.lines == nil {
+			term.lines = &lineTracker{}
+		}
+	}
+}
+
+// WithPrefix makes the terminal prepend the given prefix to every
+// line it writes. An empty prefix disables it.
+func WithPrefix(prefix string) func(*Terminal) {
+	return func(term *Terminal) {
+		term.prefix = prefix
+		if term.lines == nil {
+			term.lines = &lineTracker{}
+		}
+	}
+}
+
 // SetTerminalOptions applies options to the global terminal.
 func SetTerminalOptions(options ...func(*Terminal)) {
 	for _, option := range options {
@@ -112,14 +218,51 @@ func SetTerminalOptions(options ...func(*Terminal)) {
 	}
 }
 
-// Info writes an info output on the terminal's default writer.
-func (t Terminal) Info(a ...interface{}) {
-	if t.step != nil {
-		t.step.pushInfo(fmt.Sprint(a...))
+// output writes the given content on the writer that matches its
+// level, or queues it if a step is in progress. Debug outputs are
+// discarded when debug is disabled, but are still copied on the
+// terminal's tee. Secrets are masked before the content is wrapped,
+// since they could otherwise be split across lines.
+func (t Terminal) output(level Level, content string) {
+	content = t.redactor.Redact(content)
+
+	s := t.currentStep()
+	if s != nil {
+		t.teeLevel(level, queueIndent, content)
+	} else {
+		t.teeLevel(level, "", content)
+	}
+
+	if level == LevelDebug && !t.debug {
 		return
 	}
 
-	fmt.Fprint(t.defaultOutput, a...)
+	t.emit(Event{
+		Kind:  EventOutput,
+		Level: level,
+		Text:  content,
+	})
+
+	if s != nil {
+		s.push(level, t.wrap(content), t.decoration())
+		return
+	}
+
+	t.print(t.writerFor(level), t.wrap(content))
+}
+
+// writerFor returns the writer on which outputs of the given
+// level are written.
+func (t Terminal) writerFor(level Level) io.Writer {
+	if level == LevelError {
+		return t.errorOutput
+	}
+	return t.defaultOutput
+}
+
+// Info writes an info output on the terminal's default writer.
+func (t Terminal) Info(a ...interface{}) {
+	t.output(LevelInfo, fmt.Sprint(a...))
 }
 
 // Info writes an info output on the global terminal's default writer.
@@ -130,12 +273,7 @@ func Info(a ...interface{}) {
 // Infoln writes an info output on the terminal's default writer
 // and appends a newline to its input.
 func (t Terminal) Infoln(a ...interface{}) {
-	if t.step != nil {
-		t.step.pushInfo(fmt.Sprintln(a...))
-		return
-	}
-
-	fmt.Fprintln(t.defaultOutput, a...)
+	t.output(LevelInfo, fmt.Sprintln(a...))
 }
 
 // Infoln writes an info output on the global terminal's default writer
@@ -147,12 +285,7 @@ func Infoln(a ...interface{}) {
 // Infof formats according to a format specifier and writes
 // to the terminal's default writer.
 func (t Terminal) Infof(format string, a ...interface{}) {
-	if t.step != nil {
-		t.step.pushInfo(fmt.Sprintf(format, a...))
-		return
-	}
-
-	fmt.Fprintf(t.defaultOutput, format, a...)
+	t.output(LevelInfo, fmt.Sprintf(format, a...))
 }
 
 // Infof formats according to a format specifier and writes
@@ -164,16 +297,7 @@ func Infof(format string, a ...interface{}) {
 // Debug writes a debug output on the terminal's default writer if
 // the debug outputs are enabled.
 func (t Terminal) Debug(a ...interface{}) {
-	if !t.debug {
-		return
-	}
-
-	if t.step != nil {
-		t.step.pushDebug(fmt.Sprint(a...))
-		return
-	}
-
-	fmt.Fprint(t.defaultOutput, a...)
+	t.output(LevelDebug, fmt.Sprint(a...))
 }
 
 // Debug writes a debug output on the global terminal's default writer if
@@ -185,16 +309,7 @@ func Debug(a ...interface{}) {
 // Debugln writes a debug output on the terminal's default writer if
 // the debug outputs are enabled and appends a newline to its input.
 func (t Terminal) Debugln(a ...interface{}) {
-	if !t.debug {
-		return
-	}
-
-	if t.step != nil {
-		t.step.pushDebug(fmt.Sprintln(a...))
-		return
-	}
-
-	fmt.Fprintln(t.defaultOutput, a...)
+	t.output(LevelDebug, fmt.Sprintln(a...))
 }
 
 // Debugln writes a debug output on the global terminal's default writer if
@@ -206,16 +321,7 @@ func Debugln(a ...interface{}) {
 // Debugf formats according to a format specifier and writes
 // to the terminal's default writer if the debug outputs are enabled.
 func (t Terminal) Debugf(format string, a ...interface{}) {
-	if !t.debug {
-		return
-	}
-
-	if t.step != nil {
-		t.step.pushDebug(fmt.Sprintf(format, a...))
-		return
-	}
-
-	fmt.Fprintf(t.defaultOutput, format, a...)
+	t.output(LevelDebug, fmt.Sprintf(format, a...))
 }
 
 // Debugf formats according to a format specifier and writes
@@ -224,14 +330,35 @@ func Debugf(format string, a ...interface{}) {
 	globalTerm.Debugf(format, a...)
 }
 
-// Error writes an error output on the terminal's error writer.
-func (t Terminal) Error(a ...interface{}) {
-	if t.step != nil {
-		t.step.pushError(fmt.Sprint(a...))
+// DebugJSON writes a debug output on the terminal's default writer
+// that contains the given label followed by the value pretty-printed
+// as JSON, highlighted with the terminal's theme. Byte slices that
+// contain JSON, such as the bodies of API responses, are indented as
+// they are. The value is only marshaled if the debug outputs are
+// enabled, or if the terminal has a tee, which gets debug outputs
+// even when they are disabled.
+func (t Terminal) DebugJSON(label string, v interface{}) {
+	if !t.debug && t.teeOutput == nil {
 		return
 	}
 
-	fmt.Fprint(t.errorOutput, a...)
+	content := t.JSON(v)
+	if label != "" {
+		content = label + ":\n" + content
+	}
+	t.output(LevelDebug, content+"\n")
+}
+
+// DebugJSON writes a debug output on the global terminal's default
+// writer that contains the given label followed by the value
+// pretty-printed as JSON, if the debug outputs are enabled.
+func DebugJSON(label string, v interface{}) {
+	globalTerm.DebugJSON(label, v)
+}
+
+// Error writes an error output on the terminal's error writer.
+func (t Terminal) Error(a ...interface{}) {
+	t.output(LevelError, fmt.Sprint(a...))
 }
 
 // Error writes an error output on the global terminal's error writer.
@@ -242,12 +369,7 @@ func Error(a ...interface{}) {
 // Errorln writes an error output on the terminal's error writer.
 // It appends a newline to its input.
 func (t Terminal) Errorln(a ...interface{}) {
-	if t.step != nil {
-		t.step.pushError(fmt.Sprintln(a...))
-		return
-	}
-
-	fmt.Fprintln(t.errorOutput, a...)
+	t.output(LevelError, fmt.Sprintln(a...))
 }
 
 // Errorln writes an error output on the global terminal's error writer.
@@ -259,12 +381,7 @@ func Errorln(a ...interface{}) {
 // Errorf formats according to a format specifier and writes
 // to the terminal's error writer.
 func (t Terminal) Errorf(format string, a ...interface{}) {
-	if t.step != nil {
-		t.step.pushError(fmt.Sprintf(format, a...))
-		return
-	}
-
-	fmt.Fprintf(t.errorOutput, format, a...)
+	t.output(LevelError, fmt.Sprintf(format, a...))
 }
 
 // Errorf formats according to a format specifier and writes
@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/stretchr/testify/assert"
@@ -229,6 +230,56 @@ func TestDebugfDisabledWithoutStep(t *testing.T) {
 	assert.NotContains(t, defaultOut.String(), "element oneelement two")
 }
 
+// countingMarshaler counts how many times it is marshaled.
+type countingMarshaler struct {
+	calls *int
+}
+
+func (m countingMarshaler) MarshalJSON() ([]byte, error) {
+	*m.calls++
+	return []byte(`{"status":"ok"}`), nil
+}
+
+func TestDebugJSON(t *testing.T) {
+	defaultOut := &bytes.Buffer{}
+	calls := 0
+
+	term := &Terminal{
+		defaultOutput: defaultOut,
+		debug:         false,
+	}
+
+	// DebugJSON should not marshal anything when debug is disabled.
+	term.DebugJSON("response", countingMarshaler{&calls})
+	assert.Empty(t, defaultOut.String())
+	assert.Equal(t, 0, calls)
+
+	term.debug = true
+	term.DebugJSON("response", countingMarshaler{&calls})
+	term.DebugJSON("", []byte(`[1,2]`))
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, "response:\n{\n  \"status\": \"ok\"\n}\n[\n  1,\n  2\n]\n", defaultOut.String())
+}
+
+func TestDebugJSONTee(t *testing.T) {
+	defaultOut := &bytes.Buffer{}
+	teeOut := &bytes.Buffer{}
+
+	term := NewTerminal(WithDefaultOutput(defaultOut), WithTee(teeOut))
+	term.clock = func() time.Time {
+		return time.Date(2019, 1, 1, 12, 0, 0, 0, time.UTC)
+	}
+
+	// Debug outputs are hidden on screen, but still copied on the tee.
+	term.DebugJSON("response", []byte(`{"status":"ok"}`))
+
+	assert.Empty(t, defaultOut.String())
+	assert.Equal(t, "2019-01-01T12:00:00Z DEBUG  response:\n"+
+		"2019-01-01T12:00:00Z DEBUG  {\n"+
+		"2019-01-01T12:00:00Z DEBUG    \"status\": \"ok\"\n"+
+		"2019-01-01T12:00:00Z DEBUG  }\n", teeOut.String())
+}
+
 func TestErrorWithoutStep(t *testing.T) {
 	errorOut := &bytes.Buffer{}
 
@@ -535,12 +586,12 @@ func TestInfoWithStep(t *testing.T) {
 	term.Info("one sentence")
 	term.Info("another sentence")
 	// Since a step is in progress, outputs should be queued and not printed.
-	assert.Contains(t, term.step.queue, stepOutput{content: "one sentence", level: levelInfo})
-	assert.Contains(t, term.step.queue, stepOutput{content: "another sentence", level: levelInfo})
+	assert.Contains(t, term.step.queue, stepOutput{content: "one sentence", level: LevelInfo})
+	assert.Contains(t, term.step.queue, stepOutput{content: "another sentence", level: LevelInfo})
 	assert.NotContains(t, defaultOut.String(), "one sentenceanother sentence")
 
 	term.Info("element one", "element two")
-	assert.Contains(t, term.step.queue, stepOutput{content: "element oneelement two", level: levelInfo})
+	assert.Contains(t, term.step.queue, stepOutput{content: "element oneelement two", level: LevelInfo})
 	assert.NotContains(t, defaultOut.String(), "element oneelement two")
 
 	term.EndStep()
@@ -561,12 +612,12 @@ func TestInfolnWithStep(t *testing.T) {
 	term.Infoln("one sentence")
 	term.Infoln("another sentence")
 	// Since a step is in progress, outputs should be queued and not printed.
-	assert.Contains(t, term.step.queue, stepOutput{content: "one sentence\n", level: levelInfo})
-	assert.Contains(t, term.step.queue, stepOutput{content: "another sentence\n", level: levelInfo})
+	assert.Contains(t, term.step.queue, stepOutput{content: "one sentence\n", level: LevelInfo})
+	assert.Contains(t, term.step.queue, stepOutput{content: "another sentence\n", level: LevelInfo})
 	assert.NotContains(t, defaultOut.String(), "one sentenceanother sentence")
 
 	term.Infoln("element one", "element two")
-	assert.Contains(t, term.step.queue, stepOutput{content: "element one element two\n", level: levelInfo})
+	assert.Contains(t, term.step.queue, stepOutput{content: "element one element two\n", level: LevelInfo})
 	assert.NotContains(t, defaultOut.String(), "element one element two")
 
 	term.EndStep()
@@ -587,12 +638,12 @@ func TestInfofWithStep(t *testing.T) {
 	term.Infof("one sentence")
 	term.Infof("another sentence")
 	// Since a step is in progress, outputs should be queued and not printed.
-	assert.Contains(t, term.step.queue, stepOutput{content: "one sentence", level: levelInfo})
-	assert.Contains(t, term.step.queue, stepOutput{content: "another sentence", level: levelInfo})
+	assert.Contains(t, term.step.queue, stepOutput{content: "one sentence", level: LevelInfo})
+	assert.Contains(t, term.step.queue, stepOutput{content: "another sentence", level: LevelInfo})
 	assert.NotContains(t, defaultOut.String(), "one sentenceanother sentence")
 
 	term.Infof("element one%s", "element two")
-	assert.Contains(t, term.step.queue, stepOutput{content: "element oneelement two", level: levelInfo})
+	assert.Contains(t, term.step.queue, stepOutput{content: "element oneelement two", level: LevelInfo})
 	assert.NotContains(t, defaultOut.String(), "element oneelement two")
 
 	term.EndStep()
@@ -614,12 +665,12 @@ func TestDebugEnabledWithStep(t *testing.T) {
 	term.Debug("one sentence")
 	term.Debug("another sentence")
 	// Since a step is in progress, outputs should be queued and not printed.
-	assert.Contains(t, term.step.queue, stepOutput{content: "one sentence", level: levelDebug})
-	assert.Contains(t, term.step.queue, stepOutput{content: "another sentence", level: levelDebug})
+	assert.Contains(t, term.step.queue, stepOutput{content: "one sentence", level: LevelDebug})
+	assert.Contains(t, term.step.queue, stepOutput{content: "another sentence", level: LevelDebug})
 	assert.NotContains(t, defaultOut.String(), "one sentenceanother sentence")
 
 	term.Debug("element one", "element two")
-	assert.Contains(t, term.step.queue, stepOutput{content: "element oneelement two", level: levelDebug})
+	assert.Contains(t, term.step.queue, stepOutput{content: "element oneelement two", level: LevelDebug})
 	assert.NotContains(t, defaultOut.String(), "element oneelement two")
 
 	term.EndStep()
@@ -641,12 +692,12 @@ func TestDebuglnEnabledWithStep(t *testing.T) {
 	term.Debugln("one sentence")
 	term.Debugln("another sentence")
 	// Since a step is in progress, outputs should be queued and not printed.
-	assert.Contains(t, term.step.queue, stepOutput{content: "one sentence\n", level: levelDebug})
-	assert.Contains(t, term.step.queue, stepOutput{content: "another sentence\n", level: levelDebug})
+	assert.Contains(t, term.step.queue, stepOutput{content: "one sentence\n", level: LevelDebug})
+	assert.Contains(t, term.step.queue, stepOutput{content: "another sentence\n", level: LevelDebug})
 	assert.NotContains(t, defaultOut.String(), "one sentenceanother sentence")
 
 	term.Debugln("element one", "element two")
-	assert.Contains(t, term.step.queue, stepOutput{content: "element one element two\n", level: levelDebug})
+	assert.Contains(t, term.step.queue, stepOutput{content: "element one element two\n", level: LevelDebug})
 	assert.NotContains(t, defaultOut.String(), "element one element two")
 
 	term.EndStep()
@@ -668,12 +719,12 @@ func TestDebugfEnabledWithStep(t *testing.T) {
 	term.Debugf("one sentence")
 	term.Debugf("another sentence")
 	// Since a step is in progress, outputs should be queued and not printed.
-	assert.Contains(t, term.step.queue, stepOutput{content: "one sentence", level: levelDebug})
-	assert.Contains(t, term.step.queue, stepOutput{content: "another sentence", level: levelDebug})
+	assert.Contains(t, term.step.queue, stepOutput{content: "one sentence", level: LevelDebug})
+	assert.Contains(t, term.step.queue, stepOutput{content: "another sentence", level: LevelDebug})
 	assert.NotContains(t, defaultOut.String(), "one sentenceanother sentence")
 
 	term.Debugf("element one%s", "element two")
-	assert.Contains(t, term.step.queue, stepOutput{content: "element oneelement two", level: levelDebug})
+	assert.Contains(t, term.step.queue, stepOutput{content: "element oneelement two", level: LevelDebug})
 	assert.NotContains(t, defaultOut.String(), "element oneelement two")
 
 	term.EndStep()
@@ -695,12 +746,12 @@ func TestDebugDisabledWithStep(t *testing.T) {
 	term.Debug("one sentence")
 	term.Debug("another sentence")
 	// Since debug is disabled, even if a step is in progress, debug logs should not be queued.
-	assert.NotContains(t, term.step.queue, stepOutput{content: "one sentence", level: levelDebug})
-	assert.NotContains(t, term.step.queue, stepOutput{content: "another sentence", level: levelDebug})
+	assert.NotContains(t, term.step.queue, stepOutput{content: "one sentence", level: LevelDebug})
+	assert.NotContains(t, term.step.queue, stepOutput{content: "another sentence", level: LevelDebug})
 	assert.NotContains(t, defaultOut.String(), "one sentenceanother sentence")
 
 	term.Debug("element one", "element two")
-	assert.NotContains(t, term.step.queue, stepOutput{content: "element oneelement two", level: levelDebug})
+	assert.NotContains(t, term.step.queue, stepOutput{content: "element oneelement two", level: LevelDebug})
 	assert.NotContains(t, defaultOut.String(), "element oneelement two")
 
 	term.EndStep()
@@ -723,12 +774,12 @@ func TestDebuglnDisabledWithStep(t *testing.T) {
 	term.Debugln("one sentence")
 	term.Debugln("another sentence")
 	// Since debug is disabled, even if a step is in progress, debug logs should not be queued.
-	assert.NotContains(t, term.step.queue, stepOutput{content: "one sentence\n", level: levelDebug})
-	assert.NotContains(t, term.step.queue, stepOutput{content: "another sentence\n", level: levelDebug})
+	assert.NotContains(t, term.step.queue, stepOutput{content: "one sentence\n", level: LevelDebug})
+	assert.NotContains(t, term.step.queue, stepOutput{content: "another sentence\n", level: LevelDebug})
 	assert.NotContains(t, defaultOut.String(), "one sentenceanother sentence")
 
 	term.Debugln("element one", "element two")
-	assert.NotContains(t, term.step.queue, stepOutput{content: "element one element two\n", level: levelDebug})
+	assert.NotContains(t, term.step.queue, stepOutput{content: "element one element two\n", level: LevelDebug})
 	assert.NotContains(t, defaultOut.String(), "element one element two")
 
 	term.EndStep()
@@ -751,12 +802,12 @@ func TestDebugfDisabledWithStep(t *testing.T) {
 	term.Debugf("one sentence")
 	term.Debugf("another sentence")
 	// Since debug is disabled, even if a step is in progress, debug logs should not be queued.
-	assert.NotContains(t, term.step.queue, stepOutput{content: "one sentence", level: levelDebug})
-	assert.NotContains(t, term.step.queue, stepOutput{content: "another sentence", level: levelDebug})
+	assert.NotContains(t, term.step.queue, stepOutput{content: "one sentence", level: LevelDebug})
+	assert.NotContains(t, term.step.queue, stepOutput{content: "another sentence", level: LevelDebug})
 	assert.NotContains(t, defaultOut.String(), "one sentenceanother sentence")
 
 	term.Debugf("element one%s", "element two")
-	assert.NotContains(t, term.step.queue, stepOutput{content: "element oneelement two", level: levelDebug})
+	assert.NotContains(t, term.step.queue, stepOutput{content: "element oneelement two", level: LevelDebug})
 	assert.NotContains(t, defaultOut.String(), "element oneelement two")
 
 	term.EndStep()
@@ -779,12 +830,12 @@ func TestErrorWithStep(t *testing.T) {
 	term.Error("one sentence")
 	term.Error("another sentence")
 	// Since a step is in progress, outputs should be queued and not printed.
-	assert.Contains(t, term.step.queue, stepOutput{content: "one sentence", level: levelError})
-	assert.Contains(t, term.step.queue, stepOutput{content: "another sentence", level: levelError})
+	assert.Contains(t, term.step.queue, stepOutput{content: "one sentence", level: LevelError})
+	assert.Contains(t, term.step.queue, stepOutput{content: "another sentence", level: LevelError})
 	assert.NotContains(t, errorOut.String(), "one sentenceanother sentence")
 
 	term.Error("element one", "element two")
-	assert.Contains(t, term.step.queue, stepOutput{content: "element oneelement two", level: levelError})
+	assert.Contains(t, term.step.queue, stepOutput{content: "element oneelement two", level: LevelError})
 	assert.NotContains(t, errorOut.String(), "element oneelement two")
 
 	term.EndStep()
@@ -806,12 +857,12 @@ func TestErrorlnWithStep(t *testing.T) {
 	term.Errorln("one sentence")
 	term.Errorln("another sentence")
 	// Since a step is in progress, outputs should be queued and not printed.
-	assert.Contains(t, term.step.queue, stepOutput{content: "one sentence\n", level: levelError})
-	assert.Contains(t, term.step.queue, stepOutput{content: "another sentence\n", level: levelError})
+	assert.Contains(t, term.step.queue, stepOutput{content: "one sentence\n", level: LevelError})
+	assert.Contains(t, term.step.queue, stepOutput{content: "another sentence\n", level: LevelError})
 	assert.NotContains(t, errorOut.String(), "one sentenceanother sentence")
 
 	term.Errorln("element one", "element two")
-	assert.Contains(t, term.step.queue, stepOutput{content: "element one element two\n", level: levelError})
+	assert.Contains(t, term.step.queue, stepOutput{content: "element one element two\n", level: LevelError})
 	assert.NotContains(t, errorOut.String(), "element one element two")
 
 	term.EndStep()
@@ -833,12 +884,12 @@ func TestErrorfWithStep(t *testing.T) {
 	term.Errorf("one sentence")
 	term.Errorf("another sentence")
 	// Since a step is in progress, outputs should be queued and not printed.
-	assert.Contains(t, term.step.queue, stepOutput{content: "one sentence", level: levelError})
-	assert.Contains(t, term.step.queue, stepOutput{content: "another sentence", level: levelError})
+	assert.Contains(t, term.step.queue, stepOutput{content: "one sentence", level: LevelError})
+	assert.Contains(t, term.step.queue, stepOutput{content: "another sentence", level: LevelError})
 	assert.NotContains(t, errorOut.String(), "one sentenceanother sentence")
 
 	term.Errorf("element one%s", "element two")
-	assert.Contains(t, term.step.queue, stepOutput{content: "element oneelement two", level: levelError})
+	assert.Contains(t, term.step.queue, stepOutput{content: "element oneelement two", level: LevelError})
 	assert.NotContains(t, errorOut.String(), "element oneelement two")
 
 	term.EndStep()
@@ -861,12 +912,12 @@ func TestGlobalInfoWithStep(t *testing.T) {
 	globalTerm.Info("one sentence")
 	globalTerm.Info("another sentence")
 	// Since a step is in progress, outputs should be queued and not printed.
-	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "one sentence", level: levelInfo})
-	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "another sentence", level: levelInfo})
+	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "one sentence", level: LevelInfo})
+	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "another sentence", level: LevelInfo})
 	assert.NotContains(t, defaultOut.String(), "one sentenceanother sentence")
 
 	globalTerm.Info("element one", "element two")
-	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "element oneelement two", level: levelInfo})
+	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "element oneelement two", level: LevelInfo})
 	assert.NotContains(t, defaultOut.String(), "element oneelement two")
 
 	globalTerm.EndStep()
@@ -885,12 +936,12 @@ func TestGlobalInfolnWithStep(t *testing.T) {
 	globalTerm.Infoln("one sentence")
 	globalTerm.Infoln("another sentence")
 	// Since a step is in progress, outputs should be queued and not printed.
-	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "one sentence\n", level: levelInfo})
-	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "another sentence\n", level: levelInfo})
+	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "one sentence\n", level: LevelInfo})
+	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "another sentence\n", level: LevelInfo})
 	assert.NotContains(t, defaultOut.String(), "one sentenceanother sentence")
 
 	globalTerm.Infoln("element one", "element two")
-	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "element one element two\n", level: levelInfo})
+	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "element one element two\n", level: LevelInfo})
 	assert.NotContains(t, defaultOut.String(), "element one element two")
 
 	globalTerm.EndStep()
@@ -909,12 +960,12 @@ func TestGlobalInfofWithStep(t *testing.T) {
 	globalTerm.Infof("one sentence")
 	globalTerm.Infof("another sentence")
 	// Since a step is in progress, outputs should be queued and not printed.
-	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "one sentence", level: levelInfo})
-	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "another sentence", level: levelInfo})
+	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "one sentence", level: LevelInfo})
+	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "another sentence", level: LevelInfo})
 	assert.NotContains(t, defaultOut.String(), "one sentenceanother sentence")
 
 	globalTerm.Infof("element one%s", "element two")
-	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "element oneelement two", level: levelInfo})
+	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "element oneelement two", level: LevelInfo})
 	assert.NotContains(t, defaultOut.String(), "element oneelement two")
 
 	globalTerm.EndStep()
@@ -933,12 +984,12 @@ func TestGlobalDebugEnabledWithStep(t *testing.T) {
 	globalTerm.Debug("one sentence")
 	globalTerm.Debug("another sentence")
 	// Since a step is in progress, outputs should be queued and not printed.
-	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "one sentence", level: levelDebug})
-	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "another sentence", level: levelDebug})
+	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "one sentence", level: LevelDebug})
+	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "another sentence", level: LevelDebug})
 	assert.NotContains(t, defaultOut.String(), "one sentenceanother sentence")
 
 	globalTerm.Debug("element one", "element two")
-	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "element oneelement two", level: levelDebug})
+	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "element oneelement two", level: LevelDebug})
 	assert.NotContains(t, defaultOut.String(), "element oneelement two")
 
 	globalTerm.EndStep()
@@ -957,12 +1008,12 @@ func TestGlobalDebuglnEnabledWithStep(t *testing.T) {
 	globalTerm.Debugln("one sentence")
 	globalTerm.Debugln("another sentence")
 	// Since a step is in progress, outputs should be queued and not printed.
-	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "one sentence\n", level: levelDebug})
-	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "another sentence\n", level: levelDebug})
+	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "one sentence\n", level: LevelDebug})
+	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "another sentence\n", level: LevelDebug})
 	assert.NotContains(t, defaultOut.String(), "one sentenceanother sentence")
 
 	globalTerm.Debugln("element one", "element two")
-	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "element one element two\n", level: levelDebug})
+	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "element one element two\n", level: LevelDebug})
 	assert.NotContains(t, defaultOut.String(), "element one element two")
 
 	globalTerm.EndStep()
@@ -981,12 +1032,12 @@ func TestGlobalDebugfEnabledWithStep(t *testing.T) {
 	globalTerm.Debugf("one sentence")
 	globalTerm.Debugf("another sentence")
 	// Since a step is in progress, outputs should be queued and not printed.
-	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "one sentence", level: levelDebug})
-	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "another sentence", level: levelDebug})
+	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "one sentence", level: LevelDebug})
+	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "another sentence", level: LevelDebug})
 	assert.NotContains(t, defaultOut.String(), "one sentenceanother sentence")
 
 	globalTerm.Debugf("element one%s", "element two")
-	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "element oneelement two", level: levelDebug})
+	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "element oneelement two", level: LevelDebug})
 	assert.NotContains(t, defaultOut.String(), "element oneelement two")
 
 	globalTerm.EndStep()
@@ -1005,12 +1056,12 @@ func TestGlobalDebugDisabledWithStep(t *testing.T) {
 	globalTerm.Debug("one sentence")
 	globalTerm.Debug("another sentence")
 	// Since debug is disabled, even if a step is in progress, debug logs should not be queued.
-	assert.NotContains(t, globalTerm.step.queue, stepOutput{content: "one sentence", level: levelDebug})
-	assert.NotContains(t, globalTerm.step.queue, stepOutput{content: "another sentence", level: levelDebug})
+	assert.NotContains(t, globalTerm.step.queue, stepOutput{content: "one sentence", level: LevelDebug})
+	assert.NotContains(t, globalTerm.step.queue, stepOutput{content: "another sentence", level: LevelDebug})
 	assert.NotContains(t, defaultOut.String(), "one sentenceanother sentence")
 
 	globalTerm.Debug("element one", "element two")
-	assert.NotContains(t, globalTerm.step.queue, stepOutput{content: "element oneelement two", level: levelDebug})
+	assert.NotContains(t, globalTerm.step.queue, stepOutput{content: "element oneelement two", level: LevelDebug})
 	assert.NotContains(t, defaultOut.String(), "element oneelement two")
 
 	globalTerm.EndStep()
@@ -1030,12 +1081,12 @@ func TestGlobalDebuglnDisabledWithStep(t *testing.T) {
 	globalTerm.Debugln("one sentence")
 	globalTerm.Debugln("another sentence")
 	// Since debug is disabled, even if a step is in progress, debug logs should not be queued.
-	assert.NotContains(t, globalTerm.step.queue, stepOutput{content: "one sentence\n", level: levelDebug})
-	assert.NotContains(t, globalTerm.step.queue, stepOutput{content: "another sentence\n", level: levelDebug})
+	assert.NotContains(t, globalTerm.step.queue, stepOutput{content: "one sentence\n", level: LevelDebug})
+	assert.NotContains(t, globalTerm.step.queue, stepOutput{content: "another sentence\n", level: LevelDebug})
 	assert.NotContains(t, defaultOut.String(), "one sentenceanother sentence")
 
 	globalTerm.Debugln("element one", "element two")
-	assert.NotContains(t, globalTerm.step.queue, stepOutput{content: "element one element two\n", level: levelDebug})
+	assert.NotContains(t, globalTerm.step.queue, stepOutput{content: "element one element two\n", level: LevelDebug})
 	assert.NotContains(t, defaultOut.String(), "element one element two")
 
 	globalTerm.EndStep()
@@ -1055,12 +1106,12 @@ func TestGlobalDebugfDisabledWithStep(t *testing.T) {
 	globalTerm.Debugf("one sentence")
 	globalTerm.Debugf("another sentence")
 	// Since debug is disabled, even if a step is in progress, debug logs should not be queued.
-	assert.NotContains(t, globalTerm.step.queue, stepOutput{content: "one sentence", level: levelDebug})
-	assert.NotContains(t, globalTerm.step.queue, stepOutput{content: "another sentence", level: levelDebug})
+	assert.NotContains(t, globalTerm.step.queue, stepOutput{content: "one sentence", level: LevelDebug})
+	assert.NotContains(t, globalTerm.step.queue, stepOutput{content: "another sentence", level: LevelDebug})
 	assert.NotContains(t, defaultOut.String(), "one sentenceanother sentence")
 
 	globalTerm.Debugf("element one%s", "element two")
-	assert.NotContains(t, globalTerm.step.queue, stepOutput{content: "element oneelement two", level: levelDebug})
+	assert.NotContains(t, globalTerm.step.queue, stepOutput{content: "element oneelement two", level: LevelDebug})
 	assert.NotContains(t, defaultOut.String(), "element oneelement two")
 
 	globalTerm.EndStep()
@@ -1080,12 +1131,12 @@ func TestGlobalErrorWithStep(t *testing.T) {
 	globalTerm.Error("one sentence")
 	globalTerm.Error("another sentence")
 	// Since a step is in progress, outputs should be queued and not printed.
-	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "one sentence", level: levelError})
-	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "another sentence", level: levelError})
+	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "one sentence", level: LevelError})
+	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "another sentence", level: LevelError})
 	assert.NotContains(t, errorOut.String(), "one sentenceanother sentence")
 
 	globalTerm.Error("element one", "element two")
-	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "element oneelement two", level: levelError})
+	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "element oneelement two", level: LevelError})
 	assert.NotContains(t, errorOut.String(), "element oneelement two")
 
 	globalTerm.EndStep()
@@ -1104,12 +1155,12 @@ func TestGlobalErrorlnWithStep(t *testing.T) {
 	globalTerm.Errorln("one sentence")
 	globalTerm.Errorln("another sentence")
 	// Since a step is in progress, outputs should be queued and not printed.
-	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "one sentence\n", level: levelError})
-	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "another sentence\n", level: levelError})
+	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "one sentence\n", level: LevelError})
+	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "another sentence\n", level: LevelError})
 	assert.NotContains(t, errorOut.String(), "one sentenceanother sentence")
 
 	globalTerm.Errorln("element one", "element two")
-	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "element one element two\n", level: levelError})
+	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "element one element two\n", level: LevelError})
 	assert.NotContains(t, errorOut.String(), "element one element two")
 
 	globalTerm.EndStep()
@@ -1128,12 +1179,12 @@ func TestGlobalErrorfWithStep(t *testing.T) {
 	globalTerm.Errorf("one sentence")
 	globalTerm.Errorf("another sentence")
 	// Since a step is in progress, outputs should be queued and not printed.
-	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "one sentence", level: levelError})
-	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "another sentence", level: levelError})
+	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "one sentence", level: LevelError})
+	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "another sentence", level: LevelError})
 	assert.NotContains(t, errorOut.String(), "one sentenceanother sentence")
 
 	globalTerm.Errorf("element one%s", "element two")
-	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "element oneelement two", level: levelError})
+	assert.Contains(t, globalTerm.step.queue, stepOutput{content: "element oneelement two", level: LevelError})
 	assert.NotContains(t, errorOut.String(), "element oneelement two")
 
 	globalTerm.EndStep()
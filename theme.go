@@ -0,0 +1,77 @@
+package disgo
+
+import "github.com/Ullaakut/disgo/style"
+
+// WithTheme sets the theme that the terminal uses to style step
+// statuses and the outputs that were queued during steps. Child
+// terminals inherit their parent's theme.
+func WithTheme(theme style.Theme) func(*Terminal) {
+	return func(term *Terminal) {
+		term.theme = &theme
+	}
+}
+
+// Theme returns the terminal's theme, which is the default
+// theme unless another one was set using WithTheme. If the
+// terminal uses ASCII symbols, its symbols that are the default
+// UTF-8 ones are replaced with their ASCII equivalent, while
+// custom ones are kept. It can be used to style outputs
+// consistently with the terminal.
+func (t Terminal) Theme() style.Theme {
+	theme := style.DefaultTheme
+	if t.theme != nil {
+		theme = *t.theme
+	}
+
+	if t.asciiSymbols() {
+		theme.Symbols = asciiFallback(theme.Symbols)
+	}
+	return theme
+}
+
+// asciiFallback replaces the symbols that are the
+// default UTF-8 ones with their ASCII equivalent.
+func asciiFallback(symbols style.Symbols) style.Symbols {
+	fallback := func(symbol *string, unicode, ascii string) {
+		if *symbol == unicode {
+			*symbol = ascii
+		}
+	}
+
+	fallback(&symbols.Check, style.DefaultSymbols.Check, style.ASCIISymbols.Check)
+	fallback(&symbols.Cross, style.DefaultSymbols.Cross, style.ASCIISymbols.Cross)
+	fallback(&symbols.LeftArrow, style.DefaultSymbols.LeftArrow, style.ASCIISymbols.LeftArrow)
+	fallback(&symbols.RightArrow, style.DefaultSymbols.RightArrow, style.ASCIISymbols.RightArrow)
+	fallback(&symbols.LeftTriangle, style.DefaultSymbols.LeftTriangle, style.ASCIISymbols.LeftTriangle)
+	fallback(&symbols.RightTriangle, style.DefaultSymbols.RightTriangle, style.ASCIISymbols.RightTriangle)
+	return symbols
+}
+
+// Theme returns the theme of the global terminal.
+func Theme() style.Theme {
+	return globalTerm.Theme()
+}
+
+// JSON pretty-prints a value as indented JSON, highlighted with
+// the terminal's theme, as style.Syntax.JSON does.
+func (t Terminal) JSON(v interface{}) string {
+	return t.Theme().Syntax.JSON(v)
+}
+
+// JSON pretty-prints a value as indented JSON,
+// highlighted with the global terminal's theme.
+func JSON(v interface{}) string {
+	return globalTerm.JSON(v)
+}
+
+// YAML pretty-prints a value as YAML, highlighted with
+// the terminal's theme, as style.Syntax.YAML does.
+func (t Terminal) YAML(v interface{}) string {
+	return t.Theme().Syntax.YAML(v)
+}
+
+// YAML pretty-prints a value as YAML, highlighted
+// with the global terminal's theme.
+func YAML(v interface{}) string {
+	return globalTerm.YAML(v)
+}
@@ -0,0 +1,62 @@
+package disgo
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/Ullaakut/disgo/style"
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithTheme(t *testing.T) {
+	defaultOut := &bytes.Buffer{}
+	errorOut := &bytes.Buffer{}
+
+	color.NoColor = false
+	defer func() {
+		color.NoColor = true
+	}()
+
+	theme := style.MonochromeTheme
+	theme.StatusOK = "done"
+	theme.StatusKO = "failed"
+
+	term := NewTerminal(WithDefaultOutput(defaultOut), WithErrorOutput(errorOut), WithTheme(theme), WithASCII(false))
+	assert.Equal(t, theme, term.Theme())
+
+	term.StartStep("Installing")
+	term.Infoln("queued")
+	term.Errorln("warning")
+	term.EndStep()
+	term.StartStep("Cleaning up")
+	_ = term.FailStep(errors.New("dummy error"))
+
+	assert.Equal(t, "Installing...\x1b[1mdone\x1b[0m\n"+
+		"  > \x1b[2mqueued\x1b[0m\n"+
+		"Cleaning up...\x1b[1;7mfailed\x1b[0m\n", defaultOut.String())
+	assert.Equal(t, "  > \x1b[1;7mwarning\x1b[0m\n", errorOut.String())
+
+	// Child terminals inherit their parent's theme.
+	assert.Equal(t, theme, term.Scope("plugin").Theme())
+}
+
+func TestDefaultTheme(t *testing.T) {
+	assert.Equal(t, style.DefaultTheme, (&Terminal{ascii: asciiDisabled}).Theme())
+}
+
+func TestThemeSyntax(t *testing.T) {
+	color.NoColor = false
+	defer func() {
+		color.NoColor = true
+	}()
+
+	theme := style.MonochromeTheme
+	term := NewTerminal(WithTheme(theme))
+
+	value := map[string]int{"port": 80}
+	assert.Equal(t, theme.Syntax.JSON(value), term.JSON(value))
+	assert.Equal(t, theme.Syntax.YAML(value), term.YAML(value))
+	assert.NotEqual(t, style.JSON(value), term.JSON(value))
+}
@@ -0,0 +1,212 @@
+package transcript
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Ullaakut/disgo/style"
+)
+
+// Default colors of transcripts, and the colors of the 16
+// basic ANSI colors, as they are commonly rendered on dark
+// terminal themes.
+const (
+	defaultForeground = "#cccccc"
+	defaultBackground = "#1e1e1e"
+)
+
+var palette = [16]string{
+	"#000000", "#cd3131", "#0dbc79", "#e5e510", "#2472c8", "#bc3fbc", "#11a8cd", "#e5e5e5",
+	"#666666", "#f14c4c", "#23d18b", "#f5f543", "#3b8eea", "#d670d6", "#29b8db", "#ffffff",
+}
+
+// sgrPattern matches SGR sequences, which set the style of the text
+// that follows them. Other escape sequences are stripped.
+var sgrPattern = regexp.MustCompile(`\x1b\[([0-9;]*)m`)
+
+// sgrState is the style that SGR sequences set.
+type sgrState struct {
+	bold, faint, italic, underline, inverse bool
+	fg, bg                                  string
+}
+
+// apply updates the state with the parameters of an SGR sequence.
+func (s *sgrState) apply(params string) {
+	var codes []int
+	for _, param := range strings.Split(params, ";") {
+		// Empty parameters default to 0.
+		code, _ := strconv.Atoi(param)
+		codes = append(codes, code)
+	}
+
+	for i := 0; i < len(codes); i++ {
+		code := codes[i]
+		switch {
+		case code == 0:
+			*s = sgrState{}
+		case code == 1:
+			s.bold = true
+		case code == 2:
+			s.faint = true
+		case code == 3:
+			s.italic = true
+		case code == 4:
+			s.underline = true
+		case code == 7:
+			s.inverse = true
+		case code == 22:
+			s.bold, s.faint = false, false
+		case code == 23:
+			s.italic = false
+		case code == 24:
+			s.underline = false
+		case code == 27:
+			s.inverse = false
+		case code >= 30 && code <= 37:
+			s.fg = palette[code-30]
+		case code >= 90 && code <= 97:
+			s.fg = palette[code-90+8]
+		case code == 39:
+			s.fg = ""
+		case code >= 40 && code <= 47:
+			s.bg = palette[code-40]
+		case code >= 100 && code <= 107:
+			s.bg = palette[code-100+8]
+		case code == 49:
+			s.bg = ""
+		case code == 38 || code == 48:
+			color, n := extendedColor(codes[i+1:])
+			i += n
+			if code == 38 {
+				s.fg = color
+			} else {
+				s.bg = color
+			}
+		}
+	}
+}
+
+// extendedColor parses the parameters of a 256 colors or RGB color,
+// which follow a 38 or 48 code, and returns the color along with the
+// number of parameters that it used.
+func extendedColor(params []int) (string, int) {
+	switch {
+	case len(params) >= 2 && params[0] == 5:
+		return color256(params[1]), 2
+	case len(params) >= 4 && params[0] == 2:
+		return fmt.Sprintf("#%02x%02x%02x", clampByte(params[1]), clampByte(params[2]), clampByte(params[3])), 4
+	}
+	return "", len(params)
+}
+
+// color256 returns the color with the given index in the
+// palette of 256 colors.
+func color256(index int) string {
+	switch {
+	case index < 0 || index > 255:
+		return ""
+	case index < 16:
+		return palette[index]
+	case index < 232:
+		// 6x6x6 color cube.
+		index -= 16
+		levels := [6]int{0, 95, 135, 175, 215, 255}
+		return fmt.Sprintf("#%02x%02x%02x", levels[index/36], levels[index/6%6], levels[index%6])
+	}
+
+	// Grayscale ramp.
+	gray := 8 + (index-232)*10
+	return fmt.Sprintf("#%02x%02x%02x", gray, gray, gray)
+}
+
+func clampByte(value int) int {
+	if value < 0 {
+		return 0
+	}
+	if value > 255 {
+		return 255
+	}
+	return value
+}
+
+// css returns the inline CSS that renders the state,
+// or an empty string if it is the default style.
+func (s sgrState) css() string {
+	fg, bg := s.fg, s.bg
+	if s.inverse {
+		if fg == "" {
+			fg = defaultForeground
+		}
+		if bg == "" {
+			bg = defaultBackground
+		}
+		fg, bg = bg, fg
+	}
+
+	var properties []string
+	if fg != "" {
+		properties = append(properties, "color:"+fg)
+	}
+	if bg != "" {
+		properties = append(properties, "background-color:"+bg)
+	}
+	if s.bold {
+		properties = append(properties, "font-weight:bold")
+	}
+	if s.faint {
+		properties = append(properties, "opacity:0.6")
+	}
+	if s.italic {
+		properties = append(properties, "font-style:italic")
+	}
+	if s.underline {
+		properties = append(properties, "text-decoration:underline")
+	}
+
+	return strings.Join(properties, ";")
+}
+
+// ansiToHTML escapes text for HTML, and converts the styles that its
+// SGR sequences set into spans with equivalent inline CSS.
+func ansiToHTML(text string) string {
+	var (
+		result strings.Builder
+		state  sgrState
+		open   bool
+	)
+
+	write := func(segment string) {
+		segment = style.Strip(segment)
+		if segment == "" {
+			return
+		}
+
+		if css := state.css(); css != "" && !open {
+			result.WriteString(`<span style="` + css + `">`)
+			open = true
+		}
+		result.WriteString(html.EscapeString(segment))
+	}
+
+	last := 0
+	for _, match := range sgrPattern.FindAllStringSubmatchIndex(text, -1) {
+		write(text[last:match[0]])
+		last = match[1]
+
+		if open {
+			result.WriteString("</span>")
+			open = false
+		}
+		state.apply(text[match[2]:match[3]])
+	}
+	write(text[last:])
+
+	if open {
+		result.WriteString("</span>")
+	}
+
+	return result.String()
+}
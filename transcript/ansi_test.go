@@ -0,0 +1,57 @@
+package transcript
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestANSIToHTML(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		text     string
+		expected string
+	}{
+		{
+			desc:     "plain text is escaped",
+			text:     "<b>bold</b> & co",
+			expected: "&lt;b&gt;bold&lt;/b&gt; &amp; co",
+		},
+		{
+			desc:     "basic colors",
+			text:     "\x1b[32;1mok\x1b[0m and \x1b[91mbright\x1b[39m",
+			expected: `<span style="color:#0dbc79;font-weight:bold">ok</span> and <span style="color:#f14c4c">bright</span>`,
+		},
+		{
+			desc:     "styles accumulate",
+			text:     "\x1b[4mlink\x1b[34m blue\x1b[24m plain",
+			expected: `<span style="text-decoration:underline">link</span><span style="color:#2472c8;text-decoration:underline"> blue</span><span style="color:#2472c8"> plain</span>`,
+		},
+		{
+			desc:     "256 colors",
+			text:     "\x1b[38;5;208mcube\x1b[48;5;244mgray\x1b[m",
+			expected: `<span style="color:#ff8700">cube</span><span style="color:#ff8700;background-color:#808080">gray</span>`,
+		},
+		{
+			desc:     "RGB colors",
+			text:     "\x1b[38;2;255;128;0mrgb",
+			expected: `<span style="color:#ff8000">rgb</span>`,
+		},
+		{
+			desc:     "inverse",
+			text:     "\x1b[7minverse",
+			expected: `<span style="color:#1e1e1e;background-color:#cccccc">inverse</span>`,
+		},
+		{
+			desc:     "other escape sequences are stripped",
+			text:     "\x1b[2K\x1b[1G\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\",
+			expected: "link",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, ansiToHTML(test.text))
+		})
+	}
+}
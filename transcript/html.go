@@ -0,0 +1,120 @@
+package transcript
+
+import (
+	"html"
+	"io"
+	"strings"
+
+	"github.com/Ullaakut/disgo"
+	"github.com/Ullaakut/disgo/style"
+)
+
+// Inline CSS of the elements of HTML transcripts, so that they
+// render the same wherever they are embedded.
+const (
+	containerCSS = "background-color:" + defaultBackground + ";color:" + defaultForeground + ";font-family:monospace;padding:1em;border-radius:4px"
+	preCSS       = "margin:0;white-space:pre-wrap;font-family:inherit"
+	summaryCSS   = "cursor:pointer"
+	stepCSS      = "margin-left:2em"
+)
+
+// HTMLOptions configures how recordings are rendered as HTML.
+type HTMLOptions struct {
+	// Theme is the theme whose styles and status words are applied
+	// to step statuses and queued outputs, which should be the one
+	// of the terminal that made the recording.
+	Theme style.Theme
+}
+
+// HTMLTheme sets the theme with which recordings are rendered as HTML.
+func HTMLTheme(theme style.Theme) func(*HTMLOptions) {
+	return func(options *HTMLOptions) {
+		options.Theme = theme
+	}
+}
+
+// RenderHTML writes an HTML fragment that shows the given recording the
+// way a terminal would, with the styles of its outputs converted into
+// equivalent CSS. Each step is rendered as a collapsible element which
+// contains the outputs that were queued while it was in progress, and
+// which is expanded if the step failed. Step statuses and queued
+// outputs are rendered with style.DefaultTheme, unless specified
+// otherwise.
+func RenderHTML(w io.Writer, recording []disgo.RecordedEvent, options ...func(*HTMLOptions)) error {
+	opts := HTMLOptions{
+		Theme: style.DefaultTheme,
+	}
+	for _, option := range options {
+		option(&opts)
+	}
+	theme := opts.Theme
+
+	var doc strings.Builder
+
+	doc.WriteString(`<div class="disgo-transcript" style="` + containerCSS + `">` + "\n")
+	for _, b := range blocks(recording) {
+		if !b.step {
+			doc.WriteString(`<pre style="` + preCSS + `">` + b.text(htmlEntry(theme, false)) + "</pre>\n")
+			continue
+		}
+
+		summary := html.EscapeString(b.label) + "..." + htmlStatus(theme, b)
+		if len(b.entries) == 0 {
+			doc.WriteString(`<pre style="` + preCSS + `">` + summary + "\n</pre>\n")
+			continue
+		}
+
+		if b.status == statusKO {
+			doc.WriteString("<details open>")
+		} else {
+			doc.WriteString("<details>")
+		}
+		doc.WriteString(`<summary style="` + summaryCSS + `">` + summary + "</summary>\n")
+		doc.WriteString(`<pre style="` + preCSS + ";" + stepCSS + `">` + b.text(htmlEntry(theme, true)) + "</pre>\n")
+		doc.WriteString("</details>\n")
+	}
+	doc.WriteString("</div>\n")
+
+	_, err := io.WriteString(w, doc.String())
+	return err
+}
+
+// htmlStatus returns the status of a step, styled
+// like the terminal shows it.
+func htmlStatus(theme style.Theme, b *block) string {
+	switch b.status {
+	case statusOK:
+		return styledHTML(theme.Success, theme.StatusOK)
+	case statusKO:
+		status := styledHTML(theme.Failure, theme.StatusKO)
+		if b.err != "" {
+			status += ": " + ansiToHTML(b.err)
+		}
+		return status
+	}
+	return ""
+}
+
+// htmlEntry returns a function that renders entries. Entries that were
+// queued during a step are styled like the terminal shows them.
+func htmlEntry(theme style.Theme, queued bool) func(entry) string {
+	return func(e entry) string {
+		if !queued {
+			return ansiToHTML(e.text)
+		}
+
+		if e.level == disgo.LevelError {
+			return styledHTML(theme.Failure, e.text)
+		}
+		return styledHTML(theme.Trace, e.text)
+	}
+}
+
+// styledHTML converts text to HTML with the given style applied to it,
+// except for its trailing newline. The style is applied even though
+// colors are disabled, since they usually are on the terminal that
+// renders transcripts.
+func styledHTML(s style.Style, text string) string {
+	trimmed := strings.TrimSuffix(text, "\n")
+	return ansiToHTML(s.Render(trimmed)) + text[len(trimmed):]
+}
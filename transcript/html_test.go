@@ -0,0 +1,56 @@
+package transcript
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Ullaakut/disgo/style"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderHTML(t *testing.T) {
+	out := &bytes.Buffer{}
+	require.NoError(t, RenderHTML(out, recording))
+
+	assert.Equal(t, `<div class="disgo-transcript" style="`+containerCSS+`">`+"\n"+
+		`<pre style="`+preCSS+`"><span style="font-weight:bold">Welcome</span>`+"\n"+
+		"Install? y\n"+
+		"</pre>\n"+
+		`<pre style="`+preCSS+`">Downloading...<span style="color:#0dbc79;font-weight:bold">ok</span>`+"\n"+
+		"</pre>\n"+
+		`<details open><summary style="`+summaryCSS+`">Installing...<span style="color:#cd3131;font-weight:bold">ko</span>: exit status 2</summary>`+"\n"+
+		`<pre style="`+preCSS+`;`+stepCSS+`"><span style="color:#ffffff;opacity:0.6">running `+"`make`"+`</span>`+"\n"+
+		`<span style="color:#cd3131;font-weight:bold">&lt;missing&gt; dependency</span>`+"\n"+
+		"</pre>\n"+
+		"</details>\n"+
+		`<pre style="`+preCSS+`">Installation failed`+"\n"+
+		"</pre>\n"+
+		`<details><summary style="`+summaryCSS+`">Cleaning up...</summary>`+"\n"+
+		`<pre style="`+preCSS+`;`+stepCSS+`"><span style="color:#ffffff;opacity:0.6">`+"```"+`</span>`+"\n"+
+		"</pre>\n"+
+		"</details>\n"+
+		"</div>\n", out.String())
+}
+
+func TestRenderHTMLTheme(t *testing.T) {
+	theme := style.DefaultTheme
+	theme.Success = style.Style{"bold"}
+	theme.Failure = style.Style{"italic"}
+	theme.Trace = style.Style{}
+	theme.StatusOK = "done"
+	theme.StatusKO = "failed"
+
+	out := &bytes.Buffer{}
+	require.NoError(t, RenderHTML(out, recording[2:8], HTMLTheme(theme)))
+
+	assert.Equal(t, `<div class="disgo-transcript" style="`+containerCSS+`">`+"\n"+
+		`<pre style="`+preCSS+`">Downloading...<span style="font-weight:bold">done</span>`+"\n"+
+		"</pre>\n"+
+		`<details open><summary style="`+summaryCSS+`">Installing...<span style="font-style:italic">failed</span>: exit status 2</summary>`+"\n"+
+		`<pre style="`+preCSS+`;`+stepCSS+`">running `+"`make`"+"\n"+
+		`<span style="font-style:italic">&lt;missing&gt; dependency</span>`+"\n"+
+		"</pre>\n"+
+		"</details>\n"+
+		"</div>\n", out.String())
+}
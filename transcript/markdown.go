@@ -0,0 +1,86 @@
+package transcript
+
+import (
+	"html"
+	"io"
+	"strings"
+
+	"github.com/Ullaakut/disgo"
+	"github.com/Ullaakut/disgo/style"
+)
+
+// RenderMarkdown writes a Markdown document that shows the given
+// recording. Since Markdown does not support colors, styles are
+// stripped from outputs. Outputs are rendered in code blocks, and each
+// step is rendered as a collapsible `<details>` block which contains
+// the outputs that were queued while it was in progress, and which is
+// expanded if the step failed.
+func RenderMarkdown(w io.Writer, recording []disgo.RecordedEvent) error {
+	var parts []string
+	for _, b := range blocks(recording) {
+		if !b.step {
+			parts = append(parts, codeBlock(b.text(markdownEntry)))
+			continue
+		}
+
+		symbol := ""
+		switch b.status {
+		case statusOK:
+			symbol = style.SymbolCheck + " "
+		case statusKO:
+			symbol = style.SymbolCross + " "
+		}
+		summary := symbol + html.EscapeString(style.Strip(b.label))
+		if b.err != "" {
+			summary += ": " + html.EscapeString(style.Strip(b.err))
+		}
+
+		if len(b.entries) == 0 {
+			parts = append(parts, summary+"\n")
+			continue
+		}
+
+		details := "<details>"
+		if b.status == statusKO {
+			details = "<details open>"
+		}
+
+		// Blank lines are needed around the code block
+		// for it to be rendered as Markdown.
+		parts = append(parts, details+"\n"+
+			"<summary>"+summary+"</summary>\n\n"+
+			codeBlock(b.text(markdownEntry))+"\n"+
+			"</details>\n")
+	}
+
+	_, err := io.WriteString(w, strings.Join(parts, "\n"))
+	return err
+}
+
+func markdownEntry(e entry) string {
+	return style.Strip(e.text)
+}
+
+// codeBlock wraps text in a fenced code block, whose fence is
+// longer than any run of backticks in the text.
+func codeBlock(text string) string {
+	longest, run := 0, 0
+	for _, r := range text {
+		if r != '`' {
+			run = 0
+			continue
+		}
+
+		run++
+		if run > longest {
+			longest = run
+		}
+	}
+
+	fence := "```"
+	if longest >= len(fence) {
+		fence = strings.Repeat("`", longest+1)
+	}
+
+	return fence + "text\n" + text + fence + "\n"
+}
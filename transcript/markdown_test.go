@@ -0,0 +1,61 @@
+package transcript
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Ullaakut/disgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recording is a session in which a step succeeds, another one fails,
+// and a last one is still in progress when the recording ends.
+var recording = []disgo.RecordedEvent{
+	{Kind: "output", Level: "info", Text: "\x1b[1mWelcome\x1b[0m\n"},
+	{Kind: "prompt", Text: "Install?", Answer: "y"},
+	{Kind: "step_started", Text: "Downloading", Step: "Downloading"},
+	{Kind: "step_ended", Text: "Downloading"},
+	{Kind: "step_started", Text: "Installing", Step: "Installing"},
+	{Kind: "output", Level: "debug", Text: "running `make`\n", Step: "Installing"},
+	{Kind: "output", Level: "error", Text: "<missing> dependency\n", Step: "Installing"},
+	{Kind: "step_failed", Text: "Installing", Step: "Installing", Err: "exit status 2"},
+	{Kind: "output", Level: "error", Text: "Installation failed\n"},
+	{Kind: "step_started", Text: "Cleaning up", Step: "Cleaning up"},
+	{Kind: "output", Level: "info", Text: "```\n", Step: "Cleaning up"},
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	out := &bytes.Buffer{}
+	require.NoError(t, RenderMarkdown(out, recording))
+
+	assert.Equal(t, "```text\n"+
+		"Welcome\n"+
+		"Install? y\n"+
+		"```\n"+
+		"\n"+
+		"✔ Downloading\n"+
+		"\n"+
+		"<details open>\n"+
+		"<summary>✖ Installing: exit status 2</summary>\n"+
+		"\n"+
+		"```text\n"+
+		"running `make`\n"+
+		"<missing> dependency\n"+
+		"```\n"+
+		"\n"+
+		"</details>\n"+
+		"\n"+
+		"```text\n"+
+		"Installation failed\n"+
+		"```\n"+
+		"\n"+
+		"<details>\n"+
+		"<summary>Cleaning up</summary>\n"+
+		"\n"+
+		"````text\n"+
+		"```\n"+
+		"````\n"+
+		"\n"+
+		"</details>\n", out.String())
+}
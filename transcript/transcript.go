@@ -0,0 +1,106 @@
+// Package transcript renders session recordings made with disgo's
+// Recorder as HTML or Markdown documents, which can be attached to
+// pull request comments or CI reports.
+package transcript
+
+import (
+	"strings"
+
+	"github.com/Ullaakut/disgo"
+)
+
+// entry is an output or a prompt answer, as it was shown to the user.
+type entry struct {
+	level disgo.Level
+	text  string
+}
+
+// status is the state in which a step was when the recording ended.
+type status int
+
+const (
+	statusInProgress status = iota
+	statusOK
+	statusKO
+)
+
+// block is either a run of entries that were shown outside of
+// steps, or a step along with the entries that were queued
+// while it was in progress.
+type block struct {
+	step    bool
+	label   string
+	status  status
+	err     string
+	entries []entry
+}
+
+// blocks groups the events of a recording into blocks,
+// in the order in which they were shown.
+func blocks(recording []disgo.RecordedEvent) []*block {
+	var (
+		result  []*block
+		current *block
+		step    *block
+	)
+
+	add := func(e entry) {
+		if step != nil {
+			step.entries = append(step.entries, e)
+			return
+		}
+
+		if current == nil || current.step {
+			current = &block{}
+			result = append(result, current)
+		}
+		current.entries = append(current.entries, e)
+	}
+
+	for _, event := range recording {
+		switch event.Kind {
+		case disgo.EventStepStarted.String():
+			step = &block{step: true, label: event.Text}
+			current = step
+			result = append(result, step)
+		case disgo.EventStepEnded.String(), disgo.EventStepFailed.String():
+			if step == nil {
+				continue
+			}
+
+			step.status = statusOK
+			if event.Kind == disgo.EventStepFailed.String() {
+				step.status = statusKO
+				step.err = event.Err
+			}
+			step = nil
+		case disgo.EventOutput.String():
+			add(entry{level: level(event.Level), text: event.Text})
+		case disgo.EventPrompt.String():
+			add(entry{level: disgo.LevelInfo, text: event.Text + " " + event.Answer + "\n"})
+		}
+	}
+
+	return result
+}
+
+// level parses the name of a level.
+func level(name string) disgo.Level {
+	for _, l := range []disgo.Level{disgo.LevelDebug, disgo.LevelInfo, disgo.LevelError} {
+		if l.String() == name {
+			return l
+		}
+	}
+	return disgo.LevelInfo
+}
+
+// text returns the text of a block's entries, which always
+// ends with a single newline unless it is empty.
+func (b block) text(render func(entry) string) string {
+	var text strings.Builder
+	for _, e := range b.entries {
+		text.WriteString(render(e))
+	}
+
+	return strings.TrimRight(text.String(), "\n") + "\n"
+}
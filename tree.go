@@ -0,0 +1,18 @@
+package disgo
+
+import "github.com/Ullaakut/disgo/style"
+
+// Tree writes a tree on the terminal's default writer. Its branches
+// use ASCII characters if the terminal uses ASCII symbols, regardless
+// of the tree's ASCII field, which is left as is. Like other outputs,
+// trees are queued during steps.
+func (t Terminal) Tree(tree *style.Tree) {
+	rendered := *tree
+	rendered.ASCII = t.asciiSymbols()
+	t.Infoln(rendered)
+}
+
+// Tree writes a tree on the global terminal's default writer.
+func Tree(tree *style.Tree) {
+	globalTerm.Tree(tree)
+}
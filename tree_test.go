@@ -0,0 +1,22 @@
+package disgo
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Ullaakut/disgo/style"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTree(t *testing.T) {
+	out := &bytes.Buffer{}
+	term := NewTerminal(WithDefaultOutput(out), WithColors(false), WithASCII(false))
+
+	tree := style.NewTree("app")
+	tree.Add("main.go")
+	tree.ASCII = true
+
+	term.Tree(tree)
+
+	assert.Equal(t, "app\n└── main.go\n", out.String())
+}
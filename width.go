@@ -0,0 +1,90 @@
+package disgo
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/Ullaakut/disgo/style"
+)
+
+const (
+	// defaultWidth is the width of outputs whose width can't
+	// be detected, such as files and pipes.
+	defaultWidth = 80
+	// minWrapWidth is the width under which
+	// outputs are not wrapped any further.
+	minWrapWidth = 20
+)
+
+// WithWordWrap enables or disables word wrapping on the terminal. When
+// it is enabled, outputs are wrapped between words so that they fit in
+// the width of the terminal, including their decoration and, during
+// steps, their indentation. Continuation lines of queued outputs are
+// indented like their first line, and styles are applied again on each
+// wrapped line. Outputs that continue a line that was not ended are
+// wrapped as if they started a new one.
+func WithWordWrap(enabled bool) func(*Terminal) {
+	return func(term *Terminal) {
+		term.wordWrap = enabled
+	}
+}
+
+// Width returns the number of columns of the terminal. It is read from
+// the COLUMNS environment variable if it is set, and otherwise detected
+// from the terminal's default writer if it is a TTY. It defaults to 80
+// columns.
+func (t Terminal) Width() int {
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+
+	if columns, _, ok := t.ttySize(); ok {
+		return columns
+	}
+
+	return defaultWidth
+}
+
+// ttySize returns the number of columns and rows of the
+// terminal's default writer, if it is a TTY.
+func (t Terminal) ttySize() (int, int, bool) {
+	file, ok := t.defaultOutput.(interface{ Fd() uintptr })
+	if !ok {
+		return 0, 0, false
+	}
+	return ttySize(file.Fd())
+}
+
+// Width returns the number of columns of the global terminal.
+func Width() int {
+	return globalTerm.Width()
+}
+
+// availableWidth returns the number of columns that remain for the
+// content of outputs, once they are decorated and, during steps,
+// indented.
+func (t Terminal) availableWidth() int {
+	width := t.Width() - style.Width(t.decoration())
+	if t.currentStep() != nil {
+		width -= len(queueIndent)
+	}
+
+	if width < 1 {
+		return 1
+	}
+	return width
+}
+
+// wrap wraps content to the available width if
+// word wrapping is enabled on the terminal.
+func (t Terminal) wrap(content string) string {
+	if !t.wordWrap {
+		return content
+	}
+
+	width := t.availableWidth()
+	if width < minWrapWidth {
+		width = minWrapWidth
+	}
+	return style.Wrap(content, width)
+}
@@ -0,0 +1,10 @@
+//go:build !aix && !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !solaris && !windows
+// +build !aix,!darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!solaris,!windows
+
+package disgo
+
+// ttySize reports that the size of terminals
+// can't be detected on this platform.
+func ttySize(fd uintptr) (int, int, bool) {
+	return 0, 0, false
+}
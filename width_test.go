@@ -0,0 +1,127 @@
+package disgo
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/Ullaakut/disgo/style"
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWidth(t *testing.T) {
+	columns := os.Getenv("COLUMNS")
+	defer os.Setenv("COLUMNS", columns)
+
+	require.NoError(t, os.Setenv("COLUMNS", ""))
+	assert.Equal(t, defaultWidth, (&Terminal{defaultOutput: &bytes.Buffer{}}).Width())
+
+	require.NoError(t, os.Setenv("COLUMNS", "132"))
+	assert.Equal(t, 132, (&Terminal{defaultOutput: &bytes.Buffer{}}).Width())
+
+	require.NoError(t, os.Setenv("COLUMNS", "invalid"))
+	assert.Equal(t, defaultWidth, (&Terminal{defaultOutput: &bytes.Buffer{}}).Width())
+}
+
+func TestWithWordWrap(t *testing.T) {
+	columns := os.Getenv("COLUMNS")
+	defer os.Setenv("COLUMNS", columns)
+	require.NoError(t, os.Setenv("COLUMNS", "30"))
+
+	message := "the quick brown fox jumps over the lazy dog"
+
+	testCases := []struct {
+		desc     string
+		options  []func(*Terminal)
+		step     bool
+		expected string
+	}{
+		{
+			desc:     "disabled",
+			expected: message + "\n",
+		},
+		{
+			desc:    "enabled",
+			options: []func(*Terminal){WithWordWrap(true)},
+			expected: "the quick brown fox jumps over\n" +
+				"the lazy dog\n",
+		},
+		{
+			desc:    "decorated",
+			options: []func(*Terminal){WithWordWrap(true), WithPrefix("[app]")},
+			expected: "[app] the quick brown fox\n" +
+				"[app] jumps over the lazy dog\n",
+		},
+		{
+			desc:    "queued during a step",
+			options: []func(*Terminal){WithWordWrap(true)},
+			step:    true,
+			expected: "Installing...ok\n" +
+				"  > the quick brown fox jumps\n" +
+				"    over the lazy dog\n",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			term := NewTerminal(append([]func(*Terminal){WithDefaultOutput(out), WithColors(false)}, test.options...)...)
+
+			if test.step {
+				term.StartStep("Installing")
+			}
+			term.Infoln(message)
+			term.EndStep()
+
+			assert.Equal(t, test.expected, out.String())
+		})
+	}
+}
+
+func TestWordWrapKeepsStyles(t *testing.T) {
+	columns := os.Getenv("COLUMNS")
+	defer os.Setenv("COLUMNS", columns)
+	require.NoError(t, os.Setenv("COLUMNS", "24"))
+
+	color.NoColor = false
+	defer func() {
+		color.NoColor = true
+	}()
+
+	out := &bytes.Buffer{}
+	term := &Terminal{defaultOutput: out, wordWrap: true, ascii: asciiDisabled}
+
+	term.StartStep("Installing")
+	term.Infoln("\x1b[1mbold text that does wrap\x1b[0m")
+	term.EndStep()
+
+	assert.Equal(t, "Installing..."+style.DefaultTheme.Success.Sprint("ok")+"\n"+
+		"  > \x1b[97;2m\x1b[1mbold text that does\x1b[0m\x1b[0m\n"+
+		"    \x1b[97;2m\x1b[1mwrap\x1b[0m\x1b[0m\n", out.String())
+}
+
+func TestWordWrapMasksSecrets(t *testing.T) {
+	columns := os.Getenv("COLUMNS")
+	defer os.Setenv("COLUMNS", columns)
+	require.NoError(t, os.Setenv("COLUMNS", "20"))
+
+	out := &bytes.Buffer{}
+	term := NewTerminal(WithDefaultOutput(out), WithWordWrap(true))
+
+	// The secret is longer than the wrap width, so it would be
+	// split across lines if it was masked after being wrapped.
+	secret := "s3cr3t-0123456789abcdefghij"
+	term.RegisterSecret(secret)
+
+	term.Infoln("token:", secret)
+	term.StartStep("Creating credentials")
+	term.Infoln("token:", secret)
+	term.EndStep()
+
+	assert.NotContains(t, out.String(), "0123456789")
+	assert.Equal(t, "token: ****\n"+
+		"Creating credentials...ok\n"+
+		"  > token: ****\n", out.String())
+}
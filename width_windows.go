@@ -0,0 +1,22 @@
+//go:build windows
+// +build windows
+
+package disgo
+
+import "golang.org/x/sys/windows"
+
+// ttySize returns the number of columns and rows
+// of the console whose handle is given.
+func ttySize(fd uintptr) (int, int, bool) {
+	var info windows.ConsoleScreenBufferInfo
+	if err := windows.GetConsoleScreenBufferInfo(windows.Handle(fd), &info); err != nil {
+		return 0, 0, false
+	}
+
+	columns := int(info.Window.Right-info.Window.Left) + 1
+	rows := int(info.Window.Bottom-info.Window.Top) + 1
+	if columns <= 0 || rows <= 0 {
+		return 0, 0, false
+	}
+	return columns, rows, true
+}
@@ -0,0 +1,105 @@
+package disgo
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"sync"
+)
+
+// levelWriter is an io.Writer that buffers its input until it
+// gets a full line, and then forwards that line to a terminal
+// at a given level.
+type levelWriter struct {
+	mu sync.Mutex
+
+	term  *Terminal
+	level Level
+
+	buf []byte
+}
+
+// Write buffers p and forwards every complete line it contains
+// to the terminal. Incomplete lines are kept until they are
+// terminated or until the writer is closed.
+func (w *levelWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+
+		w.term.output(w.level, string(w.buf[:i+1]))
+		w.buf = w.buf[i+1:]
+	}
+
+	return len(p), nil
+}
+
+// Close forwards any incomplete line that is still buffered
+// to the terminal, followed by a newline.
+func (w *levelWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.buf) > 0 {
+		w.term.output(w.level, string(w.buf)+"\n")
+		w.buf = nil
+	}
+
+	return nil
+}
+
+// Writer returns a writer that forwards each line written to it
+// to the terminal at the given level. This makes it possible to
+// give the terminal to code that writes to an io.Writer, while
+// still having its outputs queued during steps and its debug outputs
+// hidden when debug is disabled.
+//
+// Lines are only forwarded once they are complete, so the writer
+// should be closed once it is no longer used, in order to flush
+// any incomplete line.
+func (t *Terminal) Writer(level Level) io.WriteCloser {
+	return &levelWriter{
+		term:  t,
+		level: level,
+	}
+}
+
+// Writer returns a writer that forwards each line written to it
+// to the global terminal at the given level.
+func Writer(level Level) io.WriteCloser {
+	return globalTerm.Writer(level)
+}
+
+// RedirectStdLog makes the standard library's default logger write
+// on the terminal at the given level. The logger's flags are cleared,
+// since its outputs are now part of the terminal's own outputs.
+//
+// It returns a function that restores the logger's previous output
+// and flags, and flushes anything that is left in the terminal's writer.
+func (t *Terminal) RedirectStdLog(level Level) func() {
+	previousOutput := log.Writer()
+	previousFlags := log.Flags()
+
+	writer := t.Writer(level)
+	log.SetOutput(writer)
+	log.SetFlags(0)
+
+	return func() {
+		log.SetOutput(previousOutput)
+		log.SetFlags(previousFlags)
+		writer.Close()
+	}
+}
+
+// RedirectStdLog makes the standard library's default logger write
+// on the global terminal at the given level.
+// Warning: This is not thread-safe.
+func RedirectStdLog(level Level) func() {
+	return globalTerm.RedirectStdLog(level)
+}
@@ -0,0 +1,114 @@
+package disgo
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriterForwardsLines(t *testing.T) {
+	defaultOut := &bytes.Buffer{}
+	errorOut := &bytes.Buffer{}
+
+	term := &Terminal{
+		defaultOutput: defaultOut,
+		errorOutput:   errorOut,
+	}
+
+	info := term.Writer(LevelInfo)
+	errs := term.Writer(LevelError)
+
+	// Incomplete lines should be buffered until they are terminated.
+	fmt.Fprint(info, "one sentence")
+	assert.Empty(t, defaultOut.String())
+
+	fmt.Fprint(info, " and its end\nanother sentence\n")
+	fmt.Fprintln(errs, "an error")
+
+	assert.Equal(t, "one sentence and its end\nanother sentence\n", defaultOut.String())
+	assert.Equal(t, "an error\n", errorOut.String())
+}
+
+func TestWriterCloseFlushesIncompleteLine(t *testing.T) {
+	defaultOut := &bytes.Buffer{}
+
+	term := &Terminal{
+		defaultOutput: defaultOut,
+	}
+
+	writer := term.Writer(LevelInfo)
+	fmt.Fprint(writer, "no newline")
+	assert.Empty(t, defaultOut.String())
+
+	assert.NoError(t, writer.Close())
+	assert.Equal(t, "no newline\n", defaultOut.String())
+
+	// Closing twice should not print anything more.
+	assert.NoError(t, writer.Close())
+	assert.Equal(t, "no newline\n", defaultOut.String())
+}
+
+func TestWriterDebugLevel(t *testing.T) {
+	defaultOut := &bytes.Buffer{}
+
+	term := &Terminal{
+		defaultOutput: defaultOut,
+	}
+
+	writer := term.Writer(LevelDebug)
+	fmt.Fprintln(writer, "hidden")
+	assert.Empty(t, defaultOut.String())
+
+	term.debug = true
+	fmt.Fprintln(writer, "shown")
+	assert.Equal(t, "shown\n", defaultOut.String())
+}
+
+func TestWriterQueuesDuringStep(t *testing.T) {
+	defaultOut := &bytes.Buffer{}
+
+	term := &Terminal{
+		defaultOutput: defaultOut,
+	}
+
+	// The writer should follow the terminal's step even if it was
+	// created before the step was started.
+	writer := term.Writer(LevelInfo)
+
+	term.StartStep("Simulated task #1")
+	fmt.Fprint(writer, "25%\n50%\n")
+	fmt.Fprintln(writer, "100%")
+	term.EndStep()
+
+	assert.Equal(t, "Simulated task #1...ok\n  > 25%\n  > 50%\n  > 100%\n", defaultOut.String())
+}
+
+func TestRedirectStdLog(t *testing.T) {
+	defaultOut := &bytes.Buffer{}
+	previousOut := &bytes.Buffer{}
+
+	log.SetOutput(previousOut)
+	log.SetFlags(log.LstdFlags)
+
+	term := &Terminal{
+		defaultOutput: defaultOut,
+	}
+
+	restore := term.RedirectStdLog(LevelInfo)
+
+	term.StartStep("Simulated task #1")
+	log.Print("from the log package")
+	term.EndStep()
+
+	assert.Equal(t, "Simulated task #1...ok\n  > from the log package\n", defaultOut.String())
+
+	restore()
+	log.Print("after restore")
+
+	assert.Equal(t, log.LstdFlags, log.Flags())
+	assert.Contains(t, previousOut.String(), "after restore")
+	assert.NotContains(t, defaultOut.String(), "after restore")
+}